@@ -39,6 +39,8 @@ func main() {
 		cli.WithExtraCommands(), // 如果有额外的命令
 		cli.WithPlugins(
 			gov4Bundle,
+			// Also available alone, e.g. to regenerate the chart with edit --plugins=helm.common.sealos.io/v3
+			helmv1.Plugin{},
 			// 可以添加其他插件
 		),
 		cli.WithDefaultPlugins(cfgv3.Version, gov4Bundle),
@@ -231,7 +231,7 @@ func GenerateRoles(ctx *genall.GenerationContext, projectName string) ([]interfa
 	clusterRoleName := fmt.Sprintf(`{{ include "%s.fullname" . }}-controllertools-clusterrole`, projectName)
 	clusterRoleBindingName := fmt.Sprintf(`{{ include "%s.fullname" . }}-controllertools-clusterrolebinding`, projectName)
 
-	saName := fmt.Sprintf(`{{ include "%s.fullname" . }}`, projectName)
+	saName := fmt.Sprintf(`{{ include "%s.serviceAccountName" . }}`, projectName)
 
 	var namespacePolicyRules []rbacv1.PolicyRule
 	var clusterPolicyRules []rbacv1.PolicyRule
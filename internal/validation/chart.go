@@ -0,0 +1,44 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"regexp"
+)
+
+// chartNameFmt follows the Helm best practices for chart names: lower case letters and numbers,
+// words separated by dashes.
+const chartNameFmt string = `[a-z][a-z0-9]*(-[a-z0-9]+)*`
+
+// chartNameMaxLen keeps the chart name usable as the default name of the resources of the chart.
+const chartNameMaxLen int = 63
+
+var chartNameRegexp = regexp.MustCompile("^" + chartNameFmt + "$")
+
+const chartNameErrMsg string = "a chart name must consist of lower case alphanumeric characters separated by " +
+	"single '-', and start with an alphabetic character"
+
+// IsChartName tests for a string that conforms to the Helm best practices for a chart name.
+func IsChartName(value string) (errs []string) {
+	if len(value) > chartNameMaxLen {
+		errs = append(errs, maxLenError(chartNameMaxLen))
+	}
+	if !chartNameRegexp.MatchString(value) {
+		errs = append(errs, regexError(chartNameErrMsg, chartNameFmt, "my-chart", "team-operator2"))
+	}
+	return errs
+}
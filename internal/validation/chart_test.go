@@ -0,0 +1,47 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsChartName", func() {
+	It("should return no error", func() {
+		for _, value := range []string{
+			"a", "chart", "my-chart", "team-operator2", "a1-b2-c3",
+			strings.Repeat("a", 63),
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsChartName(value))).To(Equal(0))
+		}
+	})
+
+	It("should return at least one error", func() {
+		for _, value := range []string{
+			"", "1chart", "-chart", "chart-", "my--chart", "My-Chart", "my_chart", "my.chart", "my chart",
+			strings.Repeat("a", 64),
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsChartName(value))).NotTo(Equal(0))
+		}
+	})
+})
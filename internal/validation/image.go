@@ -0,0 +1,46 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"regexp"
+)
+
+// imageFmt is the reference grammar of https://github.com/distribution/reference restricted to
+// [<registry>/]<repository>[:<tag>], the digests can't be set through the repository and tag values.
+const imageFmt string = `(?:` + imageDomainFmt + `/)?` + imagePathFmt + `(?:/` + imagePathFmt + `)*` +
+	`(?::[\w][\w.-]{0,127})?`
+
+const imageDomainComponentFmt string = `(?:[a-zA-Z0-9]|[a-zA-Z0-9][a-zA-Z0-9-]*[a-zA-Z0-9])`
+
+const imageDomainFmt string = imageDomainComponentFmt + `(?:\.` + imageDomainComponentFmt + `)*(?::[0-9]+)?`
+
+const imagePathFmt string = `[a-z0-9]+(?:(?:[._]|__|[-]*)[a-z0-9]+)*`
+
+var imageRegexp = regexp.MustCompile("^" + imageFmt + "$")
+
+const imageErrMsg string = "an image reference must consist of a lower case repository, optionally prefixed " +
+	"with a registry host and followed by a ':' tag"
+
+// IsImageReference tests for a string that conforms to the definition of a container image
+// reference with an optional tag, e.g. as set in the values of the helm chart.
+func IsImageReference(value string) []string {
+	if !imageRegexp.MatchString(value) {
+		return []string{regexError(imageErrMsg, imageFmt, "ghcr.io/acme/operator:v0.1.0", "operator")}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsImageReference", func() {
+	It("should return no error", func() {
+		for _, value := range []string{
+			"operator", "operator:v0.1.0", "acme/operator", "ghcr.io/acme/operator:latest",
+			"localhost:5000/operator", "localhost:5000/acme/operator:1.0.0-rc.1",
+			"registry.example.com/team_a/my-operator:v1_2", "docker.io/library/busybox",
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsImageReference(value))).To(Equal(0))
+		}
+	})
+
+	It("should return at least one error", func() {
+		for _, value := range []string{
+			"", "Operator", "acme/Operator:v1", "operator:", ":v1", "/operator", "operator/",
+			"operator:-v1", "operator@sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef",
+			"ghcr.io/acme/operator:v1:v2", " operator", "operator ",
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsImageReference(value))).NotTo(Equal(0))
+		}
+	})
+})
@@ -0,0 +1,40 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"regexp"
+)
+
+// semVerFmt is the regex suggested by https://semver.org for a SemVer 2.0.0 string.
+const semVerFmt string = `(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)` +
+	`(?:-((?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*)(?:\.(?:0|[1-9]\d*|\d*[a-zA-Z-][0-9a-zA-Z-]*))*))?` +
+	`(?:\+([0-9a-zA-Z-]+(?:\.[0-9a-zA-Z-]+)*))?`
+
+var semVerRegexp = regexp.MustCompile("^" + semVerFmt + "$")
+
+const semVerErrMsg string = "a semantic version must consist of MAJOR.MINOR.PATCH numbers without leading zeros, " +
+	"optionally followed by a '-' pre-release and a '+' build metadata suffix"
+
+// IsSemVer tests for a string that conforms to the definition of a semantic version (SemVer 2.0.0),
+// as required by Helm for the chart version.
+func IsSemVer(value string) []string {
+	if !semVerRegexp.MatchString(value) {
+		return []string{regexError(semVerErrMsg, semVerFmt, "0.1.0", "1.0.0-rc.1")}
+	}
+	return nil
+}
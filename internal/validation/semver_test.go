@@ -0,0 +1,49 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsSemVer", func() {
+	It("should return no error", func() {
+		for _, value := range []string{
+			"0.0.0", "0.1.0", "1.2.3", "10.20.30",
+			"1.0.0-alpha", "1.0.0-alpha.1", "1.0.0-rc.1", "1.0.0-0.3.7",
+			"1.0.0+20230701", "1.0.0-beta+exp.sha.5114f85",
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsSemVer(value))).To(Equal(0))
+		}
+	})
+
+	It("should return at least one error", func() {
+		for _, value := range []string{
+			"", "1", "1.2", "1.2.3.4", "v1.2.3",
+			"01.2.3", "1.02.3", "1.2.03",
+			"1.2.3-", "1.2.3+", "1.2.3-01", "1.2.3-a..b",
+			"a.b.c", " 1.2.3", "1.2.3 ",
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsSemVer(value))).NotTo(Equal(0))
+		}
+	})
+})
@@ -0,0 +1,34 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"net/url"
+)
+
+const httpURLErrMsg string = "a URL must be absolute, with an http or https scheme and a host, e.g. " +
+	"'https://github.com/acme/operator'"
+
+// IsHTTPURL tests for an absolute http or https URL, as Helm and Artifact Hub expect in the home and sources
+// of a chart.
+func IsHTTPURL(value string) []string {
+	u, err := url.Parse(value)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return []string{httpURLErrMsg}
+	}
+	return nil
+}
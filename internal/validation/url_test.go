@@ -0,0 +1,44 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package validation
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("IsHTTPURL", func() {
+	It("should return no error", func() {
+		for _, value := range []string{
+			"https://github.com/acme/operator", "http://example.com", "https://example.com:8443/docs?tab=1",
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsHTTPURL(value))).To(Equal(0))
+		}
+	})
+
+	It("should return at least one error", func() {
+		for _, value := range []string{
+			"", "github.com/acme/operator", "ftp://example.com", "https://", "/docs", "https://exa mple.com",
+		} {
+			By(fmt.Sprintf("for %s", value))
+			Expect(len(IsHTTPURL(value))).NotTo(Equal(0))
+		}
+	})
+})
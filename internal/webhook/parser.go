@@ -23,7 +23,9 @@ limitations under the License.
 package webhook
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -33,6 +35,7 @@ import (
 
 	"sigs.k8s.io/controller-tools/pkg/genall"
 	"sigs.k8s.io/controller-tools/pkg/markers"
+	"sigs.k8s.io/yaml"
 )
 
 // The default {Mutating,Validating}WebhookConfiguration version to generate.
@@ -397,9 +400,6 @@ func (g Generator) Generate(ctx *genall.GenerationContext) error {
 				Kind:    "MutatingWebhookConfiguration",
 			})
 			objRaw.SetName(fmt.Sprintf(`{{ include "%s.fullname" . }}-mutating-webhook-cfg`, g.ProjectName))
-			objRaw.SetAnnotations(map[string]string{
-				"cert-manager.io/inject-ca-from": fmt.Sprintf(`{{.Release.Namespace}}/{{ include "%s.fullname" . }}-selfsigned-issuer`, g.ProjectName),
-			})
 			objRaw.Webhooks = cfgs
 			for i := range objRaw.Webhooks {
 				// SideEffects is required in admissionregistration/v1, if this is not set or set to `Some` or `Known`,
@@ -431,9 +431,6 @@ func (g Generator) Generate(ctx *genall.GenerationContext) error {
 				Kind:    "ValidatingWebhookConfiguration",
 			})
 			objRaw.SetName(fmt.Sprintf(`{{ include "%s.fullname" . }}-validating-webhook-cfg`, g.ProjectName))
-			objRaw.SetAnnotations(map[string]string{
-				"cert-manager.io/inject-ca-from": fmt.Sprintf(`{{.Release.Namespace}}/{{ include "%s.fullname" . }}-selfsigned-issuer`, g.ProjectName),
-			})
 			objRaw.Webhooks = cfgs
 			for i := range objRaw.Webhooks {
 				// SideEffects is required in admissionregistration/v1, if this is not set or set to `Some` or `Known`,
@@ -473,13 +470,119 @@ func (g Generator) Generate(ctx *genall.GenerationContext) error {
 		} else {
 			fileName = fmt.Sprintf("webhook.%s.yaml", k)
 		}
-		if err := ctx.WriteYAML(fileName, headerText, v, genall.WithTransform(genall.TransformRemoveCreationTimestamp)); err != nil {
+		if err := writeWebhooks(ctx, fileName, headerText, g.ProjectName, v); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
+var (
+	// serviceRegexp matches the service of a webhook clientConfig with its fields
+	serviceRegexp = regexp.MustCompile(`\n    service:\n((?:      .*\n)+)`)
+	// servicePathRegexp matches the path among the fields of a webhook clientConfig service
+	servicePathRegexp = regexp.MustCompile(`(?m)^      path: (.*)$`)
+	// failurePolicyRegexp and timeoutSecondsRegexp match the failurePolicy and timeoutSeconds of a webhook
+	failurePolicyRegexp  = regexp.MustCompile(`\n  failurePolicy: (\w+)\n`)
+	timeoutSecondsRegexp = regexp.MustCompile(`\n  timeoutSeconds: (\d+)\n`)
+)
+
+// defaultTimeoutSeconds is the timeout the API server defaults the webhooks to
+const defaultTimeoutSeconds = 10
+
+// writeWebhooks writes the webhook configurations the same way genall.GenerationContext.WriteYAML does,
+// wrapped in the chart webhookEnabled condition so they are not rendered when the webhooks are disabled.
+// Their annotations are rendered by the chart webhookConfigAnnotations helper, among which the
+// inject-ca-from one when cert-manager injects the CA bundle from the serving Certificate, otherwise each
+// clientConfig gets the caBundle rendered by the chart webhookCABundle helper. The clientConfig service
+// gets its port from the webhook.service.port value, 443 for the charts without it, and is replaced by
+// the URL of the same path on webhook.ingress.host when the chart routes the webhook calls through an Ingress.
+// The failurePolicy and timeoutSeconds of the markers are overridden by the webhook.failurePolicy and
+// webhook.timeoutSeconds values when the chart sets them, and the webhooks only match the namespaces and
+// objects selected by the webhook.namespaceSelector and webhook.objectSelector values.
+func writeWebhooks(ctx *genall.GenerationContext, itemPath, headerText, projectName string, objs []interface{}) error {
+	out, err := ctx.Open(nil, itemPath)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	content := headerText + fmt.Sprintf("{{- if include \"%s.webhookEnabled\" . }}\n", projectName)
+	caBundle := fmt.Sprintf("\n  clientConfig:\n"+
+		"    {{- with include \"%s.webhookCABundle\" . }}{{ printf \"caBundle: %%s\" . | nindent 4 }}{{ end }}\n",
+		projectName)
+	annotations := fmt.Sprintf("\nmetadata:\n"+
+		"  {{- with include \"%s.webhookConfigAnnotations\" . }}\n"+
+		"  annotations:\n"+
+		"    {{- . | nindent 4 }}\n"+
+		"  {{- end }}\n",
+		projectName)
+	service := fmt.Sprintf("\n    {{- if include \"%[1]s.webhookIngress\" . }}\n"+
+		"    url: 'https://{{ .Values.webhook.ingress.host }}%%[1]s'\n"+
+		"    {{- else }}\n"+
+		"    service:\n"+
+		"      {{- dig \"service\" \"port\" 443 .Values.webhook | printf \"port: %%%%v\" | nindent 6 }}\n"+
+		"%%[2]s"+
+		"    {{- end }}\n",
+		projectName)
+	selectors := "\n  {{- with dig \"namespaceSelector\" (dict) .Values.webhook }}\n" +
+		"  namespaceSelector:\n" +
+		"    {{- toYaml . | nindent 4 }}\n" +
+		"  {{- end }}\n" +
+		"  {{- with dig \"objectSelector\" (dict) .Values.webhook }}\n" +
+		"  objectSelector:\n" +
+		"    {{- toYaml . | nindent 4 }}\n" +
+		"  {{- end }}\n" +
+		"  rules:\n"
+	for _, obj := range objs {
+		j, err := json.Marshal(obj)
+		if err != nil {
+			return fmt.Errorf("error marshaling into JSON: %v", err)
+		}
+		var raw map[string]interface{}
+		if err := json.Unmarshal(j, &raw); err != nil {
+			return err
+		}
+		if metadata, ok := raw["metadata"].(map[string]interface{}); ok {
+			delete(metadata, "creationTimestamp")
+		}
+		// The API server defaults the timeout to 10s, made explicit so webhook.timeoutSeconds can override it
+		if webhooks, ok := raw["webhooks"].([]interface{}); ok {
+			for _, w := range webhooks {
+				if w, ok := w.(map[string]interface{}); ok {
+					if _, ok := w["timeoutSeconds"]; !ok {
+						w["timeoutSeconds"] = defaultTimeoutSeconds
+					}
+				}
+			}
+		}
+		yamlContent, err := yaml.Marshal(raw)
+		if err != nil {
+			return err
+		}
+		yamlText := strings.Replace(string(yamlContent), "\nmetadata:\n", annotations, 1)
+		yamlText = strings.ReplaceAll(yamlText, "\n  clientConfig:\n", caBundle)
+		yamlText = serviceRegexp.ReplaceAllStringFunc(yamlText, func(block string) string {
+			fields := serviceRegexp.FindStringSubmatch(block)[1]
+			var path string
+			if match := servicePathRegexp.FindStringSubmatch(fields); match != nil {
+				path = match[1]
+			}
+			return fmt.Sprintf(service, path, fields)
+		})
+		yamlText = failurePolicyRegexp.ReplaceAllString(yamlText,
+			"\n  {{- dig \"failurePolicy\" \"$1\" .Values.webhook | printf \"failurePolicy: %v\" | nindent 2 }}\n")
+		yamlText = timeoutSecondsRegexp.ReplaceAllString(yamlText,
+			"\n  {{- dig \"timeoutSeconds\" $1 .Values.webhook | printf \"timeoutSeconds: %v\" | nindent 2 }}\n")
+		yamlText = strings.ReplaceAll(yamlText, "\n  rules:\n", selectors)
+		content += "---\n" + yamlText
+	}
+	content += "{{- end }}\n"
+
+	_, err = out.Write([]byte(content))
+	return err
+}
+
 func checkSideEffectsForV1(sideEffects *admissionregv1.SideEffectClass) error {
 	if sideEffects == nil {
 		return fmt.Errorf("SideEffects is required for creating v1 {Mutating,Validating}WebhookConfiguration")
@@ -20,6 +20,7 @@ import (
 	"io/ioutil"
 	"os"
 	"path"
+	"strings"
 
 	"github.com/labring/kubebuilder4helm/internal/webhook"
 
@@ -185,8 +186,31 @@ var _ = Describe("Webhook Generation From Parsing to CustomResourceDefinition",
 		}
 
 		By("loading the generated v1 YAML")
-		_, err = ioutil.ReadFile(path.Join(outputDir, "webhook.yaml"))
+		actualFile, err := ioutil.ReadFile(path.Join(outputDir, "webhook.yaml"))
 		Expect(err).NotTo(HaveOccurred())
+		Expect(string(actualFile)).To(HavePrefix("{{- if include \"helm-project.webhookEnabled\" . }}\n"))
+		Expect(string(actualFile)).To(HaveSuffix("{{- end }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring("metadata:\n" +
+			"  {{- with include \"helm-project.webhookConfigAnnotations\" . }}\n" +
+			"  annotations:\n" +
+			"    {{- . | nindent 4 }}\n" +
+			"  {{- end }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring("  clientConfig:\n" +
+			"    {{- with include \"helm-project.webhookCABundle\" . }}{{ printf \"caBundle: %s\" . | nindent 4 }}{{ end }}\n" +
+			"    {{- if include \"helm-project.webhookIngress\" . }}\n" +
+			"    url: 'https://{{ .Values.webhook.ingress.host }}/mutate-testdata-kubebuilder-io-v1-cronjob'\n" +
+			"    {{- else }}\n" +
+			"    service:\n" +
+			"      {{- dig \"service\" \"port\" 443 .Values.webhook | printf \"port: %v\" | nindent 6 }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring(
+			"  {{- dig \"failurePolicy\" \"Fail\" .Values.webhook | printf \"failurePolicy: %v\" | nindent 2 }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring(
+			"  {{- dig \"timeoutSeconds\" 10 .Values.webhook | printf \"timeoutSeconds: %v\" | nindent 2 }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring(
+			"  {{- with dig \"namespaceSelector\" (dict) .Values.webhook }}\n" +
+				"  namespaceSelector:\n" +
+				"    {{- toYaml . | nindent 4 }}\n" +
+				"  {{- end }}\n"))
 
 		By("loading the desired v1 YAML")
 		_, err = ioutil.ReadFile("webhook.yaml")
@@ -194,6 +218,47 @@ var _ = Describe("Webhook Generation From Parsing to CustomResourceDefinition",
 
 	})
 
+	It("should keep the failurePolicy and timeoutSeconds of the markers unless the values set them", func() {
+		By("switching into testdata to appease go modules")
+		cwd, err := os.Getwd()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(os.Chdir("./testdata/valid-policies")).To(Succeed()) // go modules are directory-sensitive
+		defer func() { Expect(os.Chdir(cwd)).To(Succeed()) }()
+
+		By("loading the roots")
+		pkgs, err := loader.LoadRoots(".")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(pkgs).To(HaveLen(1))
+
+		By("setting up the parser")
+		reg := &markers.Registry{}
+		Expect(webhook.Registry(reg)).To(Succeed())
+
+		By("requesting that the manifest be generated")
+		outputDir := "."
+		genCtx := &genall.GenerationContext{
+			Collector:  &markers.Collector{Registry: reg},
+			Roots:      pkgs,
+			OutputRule: genall.OutputToDirectory(outputDir),
+		}
+		Expect(webhook.Generator{ProjectName: "helm-project"}.Generate(genCtx)).To(Succeed())
+		for _, r := range genCtx.Roots {
+			Expect(r.Errors).To(HaveLen(0))
+		}
+
+		By("loading the generated v1 YAML")
+		actualFile, err := ioutil.ReadFile(path.Join(outputDir, "webhook.yaml"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(actualFile)).To(ContainSubstring(
+			"  {{- dig \"failurePolicy\" \"Ignore\" .Values.webhook | printf \"failurePolicy: %v\" | nindent 2 }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring(
+			"  {{- dig \"timeoutSeconds\" 5 .Values.webhook | printf \"timeoutSeconds: %v\" | nindent 2 }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring(
+			"  {{- dig \"failurePolicy\" \"Fail\" .Values.webhook | printf \"failurePolicy: %v\" | nindent 2 }}\n"))
+		Expect(string(actualFile)).To(ContainSubstring(
+			"  {{- dig \"timeoutSeconds\" 10 .Values.webhook | printf \"timeoutSeconds: %v\" | nindent 2 }}\n"))
+	})
+
 	It("should generate the ordered webhook definitions", func() {
 		By("switching into testdata to appease go modules")
 		cwd, err := os.Getwd()
@@ -229,16 +294,27 @@ var _ = Describe("Webhook Generation From Parsing to CustomResourceDefinition",
 			actualFile, err := ioutil.ReadFile(path.Join(outputDir, "webhook.yaml"))
 			Expect(err).NotTo(HaveOccurred())
 			actualManifest := &admissionregv1.ValidatingWebhookConfiguration{}
-			Expect(yaml.UnmarshalStrict(actualFile, actualManifest)).To(Succeed())
+			Expect(yaml.UnmarshalStrict(withoutCondition(actualFile), actualManifest)).To(Succeed())
 
 			By("loading the desired v1 YAML")
 			expectedFile, err := ioutil.ReadFile("webhook.yaml")
 			Expect(err).NotTo(HaveOccurred())
 			expectedManifest := &admissionregv1.ValidatingWebhookConfiguration{}
-			Expect(yaml.UnmarshalStrict(expectedFile, expectedManifest)).To(Succeed())
+			Expect(yaml.UnmarshalStrict(withoutCondition(expectedFile), expectedManifest)).To(Succeed())
 
 			By("comparing the manifest")
 			assertSame(actualManifest, expectedManifest)
 		}
 	})
 })
+
+// withoutCondition drops the chart condition wrapping the generated manifest so it can be parsed as YAML.
+func withoutCondition(content []byte) []byte {
+	var lines []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "{{-") {
+			lines = append(lines, line)
+		}
+	}
+	return []byte(strings.Join(lines, "\n"))
+}
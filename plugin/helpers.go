@@ -20,6 +20,7 @@ import (
 	"fmt"
 
 	"github.com/spf13/afero"
+	"github.com/spf13/pflag"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 	"sigs.k8s.io/yaml"
 )
@@ -35,7 +36,7 @@ func GetConfigExtension() ConfigExtension {
 	fs := machinery.Filesystem{FS: afero.NewOsFs()}
 	bs, err := afero.ReadFile(fs.FS, filename)
 	if err != nil {
-		fmt.Println("Using default config extension", err.Error())
+		Debugf("Using default config extension: %v", err)
 		return ConfigExtension{}
 	}
 	config := &ConfigExtension{}
@@ -43,7 +44,7 @@ func GetConfigExtension() ConfigExtension {
 	if err != nil {
 		return ConfigExtension{}
 	}
-	fmt.Println("Using config extension: isLegacyLayout", config.IsLegacyLayout)
+	Debugf("Using config extension: isLegacyLayout %t", config.IsLegacyLayout)
 	return *config
 }
 
@@ -57,3 +58,33 @@ func SetConfigExtension(config *ConfigExtension) error {
 	_ = afero.WriteFile(fs.FS, filename, bs, 0644)
 	return nil
 }
+
+// verbose is set by --verbose, shared by the bundled plugins as only one of them can bind the flag
+var verbose bool
+
+// BindVerboseFlag binds the --verbose flag enabling the diagnostics of the scaffolders, unless another bundled
+// plugin already did. It is only read once the flags are parsed, i.e. from InjectConfig on.
+func BindVerboseFlag(fs *pflag.FlagSet) {
+	if fs.Lookup("verbose") != nil {
+		return
+	}
+	fs.BoolVar(&verbose, "verbose", false, "if set, print diagnostics such as the paths of the scaffolded files")
+}
+
+// Debugf prints a diagnostic of the scaffolders when --verbose is set
+func Debugf(format string, a ...interface{}) {
+	if verbose {
+		fmt.Printf(format+"\n", a...)
+	}
+}
+
+// Execute writes the files of the builders, their paths are printed when --verbose is set
+func Execute(scaffold *machinery.Scaffold, builders ...machinery.Builder) error {
+	if err := scaffold.Execute(builders...); err != nil {
+		return err
+	}
+	for _, builder := range builders {
+		Debugf("Scaffolded %s", builder.GetPath())
+	}
+	return nil
+}
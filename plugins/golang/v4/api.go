@@ -21,7 +21,9 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
+	"github.com/labring/kubebuilder4helm/internal/validation"
 	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 
 	"github.com/spf13/pflag"
@@ -60,6 +62,42 @@ type createAPISubcommand struct {
 	// force indicates that the resource should be created even if it already exists
 	force bool
 
+	// minimal indicates that the resource types should be scaffolded without the example fields
+	minimal bool
+
+	// skipPrintColumns indicates that the resource types should be scaffolded without printer columns
+	skipPrintColumns bool
+
+	// skipFinalizer indicates that the resource types should be scaffolded without the finalizer constant
+	skipFinalizer bool
+
+	// conditionsHelpers indicates that the resource types should be scaffolded with helpers managing their conditions
+	conditionsHelpers bool
+
+	// withMetrics indicates that the controller should be scaffolded with a package for its custom metrics
+	withMetrics bool
+
+	// withScale indicates that the resource types should be scaffolded with the replicas exposed by the scale subresource
+	withScale bool
+
+	// defaultPhase is the default of the Phase of the resource status, none when empty
+	defaultPhase string
+
+	// categories are the categories the resource belongs to, e.g. listed by "kubectl get all"
+	categories []string
+
+	// shortNames are the short aliases of the resource
+	shortNames []string
+
+	// conversionHubVersion is the version of the kind the resource converts to and from,
+	// the resource is scaffolded as the hub when it is its own version
+	conversionHubVersion string
+
+	// runtimeSchemeExtra are the external API packages registered into the manager scheme,
+	// as <import path>=<alias>
+	runtimeSchemeExtra []string
+	extraSchemes       []scaffolds.SchemeImport
+
 	// runMake indicates whether to run make or not after scaffolding APIs
 	runMake bool
 
@@ -79,6 +117,18 @@ make generate will be run.
 	subcmdMeta.Examples = fmt.Sprintf(`  # Create a frigates API with Group: ship, Version: v1beta1 and Kind: Frigate
   %[1]s create api --group ship --version v1beta1 --kind Frigate
 
+  # Create a cactuses API for Kind: Cactus, instead of the cacti plural derived from the kind
+  %[1]s create api --group garden --version v1beta1 --kind Cactus --plural cactuses
+
+  # Create the v1beta2 version converting to and from the v1beta1 hub of the Frigate kind,
+  # scaffolded first with --conversion-hub-version v1beta1
+  %[1]s create api --group ship --version v1beta2 --kind Frigate --conversion-hub-version v1beta1
+
+  # Create a Frigate API whose controller owns cert-manager Certificates, registering their types
+  # into the manager scheme, go mod tidy then requires the cert-manager module
+  %[1]s create api --group ship --version v1beta1 --kind Frigate \
+    --runtime-scheme-extra github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1=certmanagerv1
+
   # Edit the API Scheme
 
   nano api/v1beta1/frigate_types.go
@@ -105,11 +155,39 @@ func (p *createAPISubcommand) BindFlags(fs *pflag.FlagSet) {
 
 	fs.BoolVar(&p.force, "force", false,
 		"attempt to create resource even if it already exists")
+	pluginsdk.BindVerboseFlag(fs)
 
 	p.options = &goPlugin.Options{}
 
 	fs.StringVar(&p.options.Plural, "plural", "", "resource irregular plural form")
 
+	fs.BoolVar(&p.minimal, "minimal", false,
+		"if set, generate the resource types without the example Foo field and Phase")
+	fs.BoolVar(&p.skipPrintColumns, "skip-print-columns", false,
+		"if set, generate the resource types without the default Phase and Age printer columns")
+	fs.BoolVar(&p.skipFinalizer, "skip-finalizer", false,
+		"if set, generate the resource types without the <Kind>Finalizer constant")
+	fs.BoolVar(&p.conditionsHelpers, "with-conditions-helpers", false,
+		"if set, generate the Set, Get and RemoveCondition helpers of the resource types")
+	fs.BoolVar(&p.withMetrics, "with-metrics", false,
+		"if set, generate a package for the custom Prometheus collectors of the controller, "+
+			"registered in the metrics registry of the manager by main.go")
+	fs.BoolVar(&p.withScale, "with-scale", false,
+		"if set, generate the resource types with Spec.Replicas, Status.Replicas and Status.Selector exposed by "+
+			"the scale subresource, so kubectl scale and the HorizontalPodAutoscaler can target the resource")
+	fs.StringVar(&p.defaultPhase, "default-phase", "Unknown", fmt.Sprintf("default of the Phase of the resource "+
+		"status, one of %s, or empty for the controller to set the initial phase", strings.Join(scaffolds.TypesPhases, ", ")))
+	fs.StringSliceVar(&p.categories, "categories", nil,
+		"comma-separated categories the resource belongs to, e.g. all")
+	fs.StringSliceVar(&p.shortNames, "short-names", nil,
+		"comma-separated short aliases of the resource, e.g. fr")
+	fs.StringVar(&p.conversionHubVersion, "conversion-hub-version", "",
+		"version of the kind the resource converts to and from, e.g. v1; the resource is scaffolded as the "+
+			"storage version implementing conversion.Hub when it is its own version, with the ConvertTo and "+
+			"ConvertFrom stubs of conversion.Convertible otherwise")
+	fs.StringSliceVar(&p.runtimeSchemeExtra, "runtime-scheme-extra", nil, "comma-separated external API packages registered into the manager scheme, "+
+		"as <import path>=<alias>, e.g. github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1=certmanagerv1")
+
 	fs.BoolVar(&p.options.DoAPI, "resource", true,
 		"if set, generate the resource without prompting the user")
 	p.resourceFlag = fs.Lookup("resource")
@@ -161,12 +239,59 @@ func (p *createAPISubcommand) InjectResource(res *resource.Resource) error {
 			return fmt.Errorf("multiple groups are not allowed by default, " +
 				"to enable multi-group visit https://kubebuilder.io/migration/multi-group.html")
 		}
+
+		// The Phase is an enum, so its default must be one of its members.
+		if p.defaultPhase != "" && !p.minimal {
+			valid := false
+			for _, phase := range scaffolds.TypesPhases {
+				if p.defaultPhase == phase {
+					valid = true
+					break
+				}
+			}
+			if !valid {
+				return fmt.Errorf("default phase (%s) is invalid: must be one of %s or empty",
+					p.defaultPhase, strings.Join(scaffolds.TypesPhases, ", "))
+			}
+		}
+
+		// Categories and short names are resource names for kubectl, so they must be DNS 1123 labels.
+		for _, category := range p.categories {
+			if err := validation.IsDNS1123Label(category); err != nil {
+				return fmt.Errorf("category (%s) is invalid: %v", category, err)
+			}
+		}
+		for _, shortName := range p.shortNames {
+			if err := validation.IsDNS1123Label(shortName); err != nil {
+				return fmt.Errorf("short name (%s) is invalid: %v", shortName, err)
+			}
+		}
+
+		// The spoke versions import the hub one, so it must be scaffolded first
+		if p.conversionHubVersion != "" && p.conversionHubVersion != p.resource.Version {
+			hub := p.resource.GVK
+			hub.Version = p.conversionHubVersion
+			if r, err := p.config.GetResource(hub); err != nil || !r.HasAPI() {
+				return fmt.Errorf("conversion hub version %s of kind %s not found, "+
+					"create its API first with --conversion-hub-version %s",
+					p.conversionHubVersion, p.resource.Kind, p.conversionHubVersion)
+			}
+		}
 	}
 
 	return nil
 }
 
 func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
+	// The aliases name the imports of main.go, so they must be valid Go identifiers.
+	for _, value := range p.runtimeSchemeExtra {
+		extra, err := scaffolds.ParseSchemeImport(value)
+		if err != nil {
+			return fmt.Errorf("runtime scheme (%s) is invalid: %v", value, err)
+		}
+		p.extraSchemes = append(p.extraSchemes, extra)
+	}
+
 	maingo := DefaultMainPath
 	// check if main.go is present in the root directory
 	if p.extConfig.IsLegacyLayout {
@@ -180,7 +305,9 @@ func (p *createAPISubcommand) PreScaffold(machinery.Filesystem) error {
 }
 
 func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewAPIScaffolder(p.config, *p.resource, p.force, p.extConfig)
+	scaffolder := scaffolds.NewAPIScaffolder(p.config, *p.resource, p.force, p.minimal, p.skipPrintColumns,
+		p.skipFinalizer, p.conditionsHelpers, p.withMetrics, p.withScale, p.defaultPhase, p.categories, p.shortNames,
+		p.conversionHubVersion, p.extraSchemes, p.extConfig)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/pflag"
 
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"github.com/labring/kubebuilder4helm/plugins/golang/v4/scaffolds"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
@@ -34,6 +35,11 @@ type editSubcommand struct {
 
 	multigroup     bool
 	isLegacyLayout bool
+
+	// Keep track of the flags to only toggle the layouts that were passed, e.g. not when the bundled
+	// helm plugin regenerates the chart
+	multigroupFlag *pflag.Flag
+	legacyFlag     *pflag.Flag
 }
 
 func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -58,6 +64,9 @@ Features supported:
 func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&p.multigroup, "multigroup", false, "enable or disable multigroup layout")
 	fs.BoolVar(&p.isLegacyLayout, "legacy", false, "enable or disable legacy layout")
+	pluginsdk.BindVerboseFlag(fs)
+	p.multigroupFlag = fs.Lookup("multigroup")
+	p.legacyFlag = fs.Lookup("legacy")
 }
 
 func (p *editSubcommand) InjectConfig(c config.Config) error {
@@ -67,6 +76,13 @@ func (p *editSubcommand) InjectConfig(c config.Config) error {
 }
 
 func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if !p.multigroupFlag.Changed {
+		p.multigroup = p.config.IsMultiGroup()
+	}
+	if !p.legacyFlag.Changed {
+		p.isLegacyLayout = pluginsdk.GetConfigExtension().IsLegacyLayout
+	}
+
 	scaffolder := scaffolds.NewEditScaffolder(p.config, p.multigroup, p.isLegacyLayout)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
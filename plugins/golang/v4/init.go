@@ -25,8 +25,11 @@ import (
 
 	"github.com/spf13/pflag"
 
+	"github.com/labring/kubebuilder4helm/internal/validation"
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"github.com/labring/kubebuilder4helm/plugins/golang"
 	"github.com/labring/kubebuilder4helm/plugins/golang/v4/scaffolds"
+	helmv3 "github.com/labring/kubebuilder4helm/plugins/helm/v3"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v3/pkg/plugin"
@@ -54,9 +57,14 @@ type initSubcommand struct {
 	repo string
 
 	// flags
-	fetchDeps          bool
-	skipGoVersionCheck bool
-	isLegacyLayout     bool
+	fetchDeps                     bool
+	skipGoVersionCheck            bool
+	isLegacyLayout                bool
+	tracing                       bool
+	leaderElectionID              string
+	leaderElectionReleaseOnCancel bool
+	runtimeSchemeExtra            []string
+	extraSchemes                  []scaffolds.SchemeImport
 }
 
 func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -81,6 +89,7 @@ func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&p.skipGoVersionCheck, "skip-go-version-check",
 		false, "if specified, skip checking the Go version")
+	pluginsdk.BindVerboseFlag(fs)
 
 	// dependency args
 	fs.BoolVar(&p.fetchDeps, "fetch-deps", true, "ensure dependencies are downloaded")
@@ -96,6 +105,20 @@ func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 
 	// legacy layout arg
 	fs.BoolVar(&p.isLegacyLayout, "legacy", false, "if specified, use the legacy project layout")
+
+	// tracing arg
+	fs.BoolVar(&p.tracing, "tracing", false, "if specified, scaffold the OpenTelemetry tracing bootstrap in main.go")
+
+	// leader election arg
+	fs.StringVar(&p.leaderElectionID, "leader-election-id", "", "default name of the leader election Lease "+
+		"of the manager, derived from the repository and the domain if unset")
+	fs.BoolVar(&p.leaderElectionReleaseOnCancel, "leader-election-release-on-cancel", false, "if specified, "+
+		"the leader steps down voluntarily when the manager ends for faster failovers, only safe when the "+
+		"manager exits right after it stops")
+
+	// runtime scheme args
+	fs.StringSliceVar(&p.runtimeSchemeExtra, "runtime-scheme-extra", nil, "comma-separated external API packages registered into the manager scheme, "+
+		"as <import path>=<alias>, e.g. github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1=certmanagerv1")
 }
 
 func (p *initSubcommand) InjectConfig(c config.Config) error {
@@ -121,12 +144,38 @@ func (p *initSubcommand) PreScaffold(machinery.Filesystem) error {
 		}
 	}
 
+	// The Lease is named after the leader election ID, so it must be a valid object name (DNS 1123 subdomain)
+	// qualified by the domain of the project like the default one. It is stored in the PROJECT file for the
+	// helm chart to pass the same one to the manager.
+	if p.leaderElectionID != "" {
+		if err := validation.IsDNS1123Subdomain(p.leaderElectionID); err != nil {
+			return fmt.Errorf("leader election ID (%s) is invalid: %v", p.leaderElectionID, err)
+		}
+		if domain := p.config.GetDomain(); !strings.HasSuffix(p.leaderElectionID, "."+domain) {
+			return fmt.Errorf("leader election ID (%s) is invalid: must belong to the domain of the project, "+
+				"e.g. <name>.%s", p.leaderElectionID, domain)
+		}
+		if err := helmv3.SetLeaderElectionID(p.config, p.leaderElectionID); err != nil {
+			return err
+		}
+	}
+
+	// The aliases name the imports of main.go, so they must be valid Go identifiers.
+	for _, value := range p.runtimeSchemeExtra {
+		extra, err := scaffolds.ParseSchemeImport(value)
+		if err != nil {
+			return fmt.Errorf("runtime scheme (%s) is invalid: %v", value, err)
+		}
+		p.extraSchemes = append(p.extraSchemes, extra)
+	}
+
 	// Check if the current directory has not files or directories which does not allow to init the project
 	return checkDir()
 }
 
 func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewInitScaffolder(p.config, p.license, p.owner, p.isLegacyLayout)
+	scaffolder := scaffolds.NewInitScaffolder(p.config, p.license, p.owner, p.isLegacyLayout, p.tracing,
+		p.leaderElectionID, p.leaderElectionReleaseOnCancel, p.extraSchemes)
 	scaffolder.InjectFS(fs)
 	err := scaffolder.Scaffold()
 	if err != nil {
@@ -150,6 +199,16 @@ func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
 	if err != nil {
 		return err
 	}
+
+	if p.tracing {
+		err = util.RunCmd("Get OpenTelemetry", "go", "get",
+			"go.opentelemetry.io/otel@"+scaffolds.OpenTelemetryVersion,
+			"go.opentelemetry.io/otel/sdk@"+scaffolds.OpenTelemetryVersion,
+			"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc@"+scaffolds.OpenTelemetryVersion)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
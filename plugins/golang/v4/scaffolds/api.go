@@ -33,6 +33,9 @@ import (
 	"sigs.k8s.io/kubebuilder/v3/pkg/plugins"
 )
 
+// TypesPhases are the phases the Status Phase of the API types can default to
+var TypesPhases = api.Phases
+
 var _ plugins.Scaffolder = &apiScaffolder{}
 
 // apiScaffolder contains configuration for generating scaffolding for Go type
@@ -47,17 +50,61 @@ type apiScaffolder struct {
 	// force indicates whether to scaffold controller files even if it exists or not
 	force bool
 
+	// minimal indicates whether to scaffold the API types without the example fields
+	minimal bool
+
+	// skipPrintColumns indicates whether to scaffold the API types without the default printer columns
+	skipPrintColumns bool
+
+	// skipFinalizer indicates whether to scaffold the API types without the finalizer constant
+	skipFinalizer bool
+
+	// conditionsHelpers indicates whether to scaffold the helpers managing the conditions of the API types
+	conditionsHelpers bool
+
+	// withMetrics indicates whether to scaffold the custom metrics of the controller and register them in main.go
+	withMetrics bool
+
+	// withScale indicates whether to scaffold the API types with the replicas exposed by the scale subresource
+	withScale bool
+
+	// defaultPhase is the default of the Phase of the API types status, none when empty
+	defaultPhase string
+
+	// categories and shortNames are added to the resource marker of the API types
+	categories []string
+	shortNames []string
+
+	// conversionHubVersion is the version of the kind the API types convert to and from, none when empty
+	conversionHubVersion string
+
+	// extraSchemes are the external API packages registered into the manager scheme
+	extraSchemes []SchemeImport
+
 	// extension points for plugins to customize the scaffolding behavior
 	extConfig pluginsdk.ConfigExtension
 }
 
 // NewAPIScaffolder returns a new Scaffolder for API/controller creation operations
-func NewAPIScaffolder(config config.Config, res resource.Resource, force bool, extConfig pluginsdk.ConfigExtension) plugins.Scaffolder {
+func NewAPIScaffolder(config config.Config, res resource.Resource, force, minimal, skipPrintColumns, skipFinalizer,
+	conditionsHelpers, withMetrics, withScale bool, defaultPhase string, categories, shortNames []string, conversionHubVersion string,
+	extraSchemes []SchemeImport, extConfig pluginsdk.ConfigExtension) plugins.Scaffolder {
 	return &apiScaffolder{
-		config:    config,
-		resource:  res,
-		force:     force,
-		extConfig: extConfig,
+		config:               config,
+		resource:             res,
+		force:                force,
+		minimal:              minimal,
+		skipPrintColumns:     skipPrintColumns,
+		skipFinalizer:        skipFinalizer,
+		conditionsHelpers:    conditionsHelpers,
+		withMetrics:          withMetrics,
+		withScale:            withScale,
+		defaultPhase:         defaultPhase,
+		categories:           categories,
+		shortNames:           shortNames,
+		conversionHubVersion: conversionHubVersion,
+		extraSchemes:         extraSchemes,
+		extConfig:            extConfig,
 	}
 }
 
@@ -96,25 +143,58 @@ func (s *apiScaffolder) Scaffold() error {
 	}
 
 	if doAPI {
-		if err := scaffold.Execute(
-			&api.Types{Force: s.force},
+		if err := pluginsdk.Execute(scaffold,
+			&api.Types{
+				Minimal:          s.minimal,
+				SkipPrintColumns: s.skipPrintColumns,
+				SkipFinalizer:    s.skipFinalizer,
+				Scale:            s.withScale,
+				DefaultPhase:     s.defaultPhase,
+				Categories:       s.categories,
+				ShortNames:       s.shortNames,
+				StorageVersion:   s.conversionHubVersion == s.resource.Version,
+				Force:            s.force,
+			},
 			&api.Group{},
 		); err != nil {
 			return fmt.Errorf("error scaffolding APIs: %v", err)
 		}
+
+		if s.conditionsHelpers {
+			if err := pluginsdk.Execute(scaffold, &api.Conditions{Force: s.force}); err != nil {
+				return fmt.Errorf("error scaffolding API conditions helpers: %v", err)
+			}
+		}
+
+		if s.conversionHubVersion != "" {
+			if err := pluginsdk.Execute(scaffold,
+				&api.Conversion{HubVersion: s.conversionHubVersion, Force: s.force},
+			); err != nil {
+				return fmt.Errorf("error scaffolding API conversion: %v", err)
+			}
+		}
 	}
 
 	if doController {
-		if err := scaffold.Execute(
+		if err := pluginsdk.Execute(scaffold,
 			&controllers.SuiteTest{Force: s.force, IsLegacyLayout: s.extConfig.IsLegacyLayout},
 			&controllers.Controller{ControllerRuntimeVersion: ControllerRuntimeVersion, EndpointOperatorLibVersion: EndpointOperatorLibVersion, Force: s.force, IsLegacyLayout: s.extConfig.IsLegacyLayout},
 		); err != nil {
 			return fmt.Errorf("error scaffolding controller: %v", err)
 		}
+
+		if s.withMetrics {
+			if err := pluginsdk.Execute(scaffold,
+				&templates.Metrics{Force: s.force, IsLegacyLayout: s.extConfig.IsLegacyLayout},
+			); err != nil {
+				return fmt.Errorf("error scaffolding controller metrics: %v", err)
+			}
+		}
 	}
 
-	if err := scaffold.Execute(
-		&templates.MainUpdater{WireResource: doAPI, WireController: doController, IsLegacyLayout: s.extConfig.IsLegacyLayout},
+	if err := pluginsdk.Execute(scaffold,
+		&templates.MainUpdater{WireResource: doAPI, WireController: doController, WireMetrics: doController && s.withMetrics,
+			ExtraSchemes: s.extraSchemes, IsLegacyLayout: s.extConfig.IsLegacyLayout},
 	); err != nil {
 		return fmt.Errorf("error updating cmd/main.go: %v", err)
 	}
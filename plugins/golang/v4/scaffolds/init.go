@@ -18,8 +18,12 @@ package scaffolds
 
 import (
 	"fmt"
+	"go/token"
+	"strings"
+	"time"
 
 	"github.com/labring/kubebuilder4helm/internal/version"
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"github.com/spf13/afero"
 
 	"github.com/labring/kubebuilder4helm/plugins/golang/v4/scaffolds/internal/templates"
@@ -32,15 +36,41 @@ import (
 
 const (
 	// ControllerRuntimeVersion is the kubernetes-sigs/controller-runtime version to be used in the project
-	ControllerRuntimeVersion = "v0.15.0"
+	ControllerRuntimeVersion = "v0.16.3"
 	// ControllerToolsVersion is the kubernetes-sigs/controller-tools version to be used in the project
-	ControllerToolsVersion = "v0.12.0"
+	ControllerToolsVersion = "v0.13.0"
+	// KubeconformVersion is the yannh/kubeconform version validating the rendered chart in the project
+	KubeconformVersion = "v0.6.4"
 	// EndpointOperatorLibVersion is the labring/operator-sdk version to be used in the project
 	EndpointOperatorLibVersion = "v1.0.1"
+	// OpenTelemetryVersion is the go.opentelemetry.io/otel version to be used when tracing is scaffolded,
+	// it must match the one required by k8s.io/component-base which is pulled by controller-runtime
+	OpenTelemetryVersion = "v1.10.0"
 
 	imageName = "controller:latest"
+
+	// rate limiter defaults baked into main.go, they match the operator-sdk ones
+	minRetryDelay    = 5 * time.Millisecond
+	maxRetryDelay    = 1000 * time.Second
+	rateLimiterBurst = 100
 )
 
+// SchemeImport is an external API package registered into the manager scheme
+type SchemeImport = templates.SchemeImport
+
+// ParseSchemeImport parses an external API package from <import path>=<alias>,
+// e.g. github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1=certmanagerv1.
+func ParseSchemeImport(value string) (SchemeImport, error) {
+	importPath, alias, ok := strings.Cut(value, "=")
+	if !ok || importPath == "" || alias == "" {
+		return SchemeImport{}, fmt.Errorf("expected <import path>=<alias>")
+	}
+	if !token.IsIdentifier(alias) {
+		return SchemeImport{}, fmt.Errorf("alias %q is not a valid Go identifier", alias)
+	}
+	return SchemeImport{Path: importPath, Alias: alias}, nil
+}
+
 var _ plugins.Scaffolder = &initScaffolder{}
 
 var helmVersion string
@@ -51,18 +81,30 @@ type initScaffolder struct {
 	license         string
 	owner           string
 	isLegacyLayout  bool
+	tracing         bool
+	// leaderElectionID overrides the default leader election Lease name of the manager
+	leaderElectionID string
+	// leaderElectionReleaseOnCancel makes the leader step down voluntarily when the manager ends
+	leaderElectionReleaseOnCancel bool
+	// extraSchemes are the external API packages registered into the manager scheme
+	extraSchemes []SchemeImport
 	// fs is the filesystem that will be used by the scaffolder
 	fs machinery.Filesystem
 }
 
 // NewInitScaffolder returns a new Scaffolder for project initialization operations
-func NewInitScaffolder(config config.Config, license, owner string, isLegacyLayout bool) plugins.Scaffolder {
+func NewInitScaffolder(config config.Config, license, owner string, isLegacyLayout, tracing bool,
+	leaderElectionID string, leaderElectionReleaseOnCancel bool, extraSchemes []SchemeImport) plugins.Scaffolder {
 	return &initScaffolder{
-		config:          config,
-		boilerplatePath: hack.DefaultBoilerplatePath,
-		license:         license,
-		owner:           owner,
-		isLegacyLayout:  isLegacyLayout,
+		config:                        config,
+		boilerplatePath:               hack.DefaultBoilerplatePath,
+		license:                       license,
+		owner:                         owner,
+		isLegacyLayout:                isLegacyLayout,
+		tracing:                       tracing,
+		leaderElectionID:              leaderElectionID,
+		leaderElectionReleaseOnCancel: leaderElectionReleaseOnCancel,
+		extraSchemes:                  extraSchemes,
 	}
 }
 
@@ -88,7 +130,7 @@ func (s *initScaffolder) Scaffold() error {
 			Owner:   s.owner,
 		}
 		bpFile.Path = s.boilerplatePath
-		if err := scaffold.Execute(bpFile); err != nil {
+		if err := pluginsdk.Execute(scaffold, bpFile); err != nil {
 			return err
 		}
 
@@ -124,12 +166,45 @@ func (s *initScaffolder) Scaffold() error {
 	//	}
 	//}
 
-	return scaffold.Execute(
-		&templates.Main{IsLegacyLayout: s.isLegacyLayout},
-		&templates.GoMod{
-			ControllerRuntimeVersion:   ControllerRuntimeVersion,
-			EndpointOperatorLibVersion: EndpointOperatorLibVersion,
+	goMod := &templates.GoMod{
+		ControllerRuntimeVersion:   ControllerRuntimeVersion,
+		EndpointOperatorLibVersion: EndpointOperatorLibVersion,
+	}
+	if s.tracing {
+		goMod.OpenTelemetryVersion = OpenTelemetryVersion
+	}
+
+	chartName, err := helmv3.ChartName(s.config)
+	if err != nil {
+		return err
+	}
+	chartDir, err := helmv3.ChartDir(s.config)
+	if err != nil {
+		return err
+	}
+	healthProbePathPrefix, err := helmv3.HealthProbePathPrefix(s.config)
+	if err != nil {
+		return err
+	}
+	crdsDir, err := helmv3.CRDsDir(s.config)
+	if err != nil {
+		return err
+	}
+
+	return pluginsdk.Execute(scaffold,
+		&templates.Main{
+			IsLegacyLayout:                s.isLegacyLayout,
+			Tracing:                       s.tracing,
+			LeaderElectionID:              s.leaderElectionID,
+			LeaderElectionReleaseOnCancel: s.leaderElectionReleaseOnCancel,
+			MinRetryDelay:                 minRetryDelay,
+			MaxRetryDelay:                 maxRetryDelay,
+			Burst:                         rateLimiterBurst,
+			ExtraSchemes:                  s.extraSchemes,
+			HealthProbePathPrefix:         healthProbePathPrefix,
 		},
+		&templates.FeatureGates{IsLegacyLayout: s.isLegacyLayout},
+		goMod,
 		&templates.GitIgnore{},
 		&templates.Makefile{
 			Image:                       imageName,
@@ -137,6 +212,10 @@ func (s *initScaffolder) Scaffold() error {
 			ControllerToolsVersion:      ControllerToolsVersion,
 			ControllerToolsVersion4Helm: version.String(),
 			HelmVersion:                 helmVersion,
+			KubeconformVersion:          KubeconformVersion,
+			ChartName:                   chartName,
+			ChartDir:                    chartDir,
+			CRDsDir:                     crdsDir,
 			ControllerRuntimeVersion:    ControllerRuntimeVersion,
 			EndpointOperatorLibVersion:  EndpointOperatorLibVersion,
 			IsLegacyLayout:              s.isLegacyLayout,
@@ -0,0 +1,85 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Conditions{}
+
+// Conditions scaffolds the file that defines the helpers managing the status conditions of a CRD
+type Conditions struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *Conditions) SetTemplateDefaults() error {
+	if f.Path == "" {
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join("api", "%[group]", "%[version]", "%[kind]_conditions.go")
+		} else {
+			f.Path = filepath.Join("api", "%[version]", "%[kind]_conditions.go")
+		}
+	}
+
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	f.TemplateBody = conditionsTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.Error
+	}
+
+	return nil
+}
+
+const conditionsTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+import (
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SetCondition adds or updates the condition of the same type in the status of the {{ .Resource.Kind }}.
+// The LastTransitionTime is set to now when the status of the condition changes, or when unset.
+func (r *{{ .Resource.Kind }}) SetCondition(condition metav1.Condition) {
+	meta.SetStatusCondition(&r.Status.Conditions, condition)
+}
+
+// GetCondition returns the condition of the given type in the status of the {{ .Resource.Kind }},
+// or nil when there is none.
+func (r *{{ .Resource.Kind }}) GetCondition(conditionType string) *metav1.Condition {
+	return meta.FindStatusCondition(r.Status.Conditions, conditionType)
+}
+
+// RemoveCondition removes the condition of the given type from the status of the {{ .Resource.Kind }}.
+func (r *{{ .Resource.Kind }}) RemoveCondition(conditionType string) {
+	meta.RemoveStatusCondition(&r.Status.Conditions, conditionType)
+}
+`
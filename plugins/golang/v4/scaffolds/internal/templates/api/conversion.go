@@ -0,0 +1,117 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"path"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Conversion{}
+
+// Conversion scaffolds the file that implements the conversion interfaces of a CRD served in several versions,
+// the Hub method on the storage version or the ConvertTo and ConvertFrom stubs on the other ones
+type Conversion struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	// HubVersion is the version the resource converts to and from, the resource is the hub when it is its own
+	HubVersion string
+	// HubPackage is the import path of the hub version, set for the spoke versions
+	HubPackage string
+
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *Conversion) SetTemplateDefaults() error {
+	if f.Path == "" {
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join("api", "%[group]", "%[version]", "%[kind]_conversion.go")
+		} else {
+			f.Path = filepath.Join("api", "%[version]", "%[kind]_conversion.go")
+		}
+	}
+
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	if f.IsHub() {
+		f.TemplateBody = hubTemplate
+	} else {
+		// The versions of a kind are sibling packages
+		f.HubPackage = path.Join(path.Dir(f.Resource.Path), f.HubVersion)
+		f.TemplateBody = spokeTemplate
+	}
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.Error
+	}
+
+	return nil
+}
+
+// IsHub reports whether the resource is the hub the other versions convert to and from
+func (f *Conversion) IsHub() bool {
+	return f.HubVersion == "" || f.HubVersion == f.Resource.Version
+}
+
+const hubTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+// Hub marks {{ .Resource.Kind }} as the conversion hub, the other versions of the kind implement
+// conversion.Convertible to convert to and from it. The hub should also be the storage version.
+func (*{{ .Resource.Kind }}) Hub() {}
+`
+
+const spokeTemplate = `{{ .Boilerplate }}
+
+package {{ .Resource.Version }}
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	{{ .HubVersion }} "{{ .HubPackage }}"
+)
+
+// ConvertTo converts this {{ .Resource.Kind }} to the hub version ({{ .HubVersion }}).
+func (src *{{ .Resource.Kind }}) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*{{ .HubVersion }}.{{ .Resource.Kind }})
+	dst.ObjectMeta = src.ObjectMeta
+
+	// TODO(user): convert the Spec and the Status fields, the ones missing from this version
+	// can be kept in an annotation to be restored by ConvertFrom so round trips are lossless.
+
+	return nil
+}
+
+// ConvertFrom converts the hub version ({{ .HubVersion }}) to this {{ .Resource.Kind }}.
+func (dst *{{ .Resource.Kind }}) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*{{ .HubVersion }}.{{ .Resource.Kind }})
+	dst.ObjectMeta = src.ObjectMeta
+
+	// TODO(user): convert the Spec and the Status fields.
+
+	return nil
+}
+`
@@ -17,14 +17,17 @@ limitations under the License.
 package api
 
 import (
-	"fmt"
 	"path/filepath"
+	"strings"
 
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
 var _ machinery.Template = &Types{}
 
+// Phases are the members of the <Kind>Phase enum of the types
+var Phases = []string{"Pending", "Unknown", "Active"}
+
 // Types scaffolds the file that defines the schema for a CRD
 // nolint:maligned
 type Types struct {
@@ -33,6 +36,24 @@ type Types struct {
 	machinery.BoilerplateMixin
 	machinery.ResourceMixin
 
+	// Minimal skips the example Foo field and Phase enum, leaving an empty Spec
+	// and a Status with only the Conditions
+	Minimal bool
+	// SkipPrintColumns skips the default Phase and Age printer columns
+	SkipPrintColumns bool
+	// SkipFinalizer skips the <Kind>Finalizer constant derived from the resource group and kind
+	SkipFinalizer bool
+	// Categories are added to the resource marker, e.g. to be listed by "kubectl get all"
+	Categories []string
+	// ShortNames are added to the resource marker as aliases of the resource
+	ShortNames []string
+	// DefaultPhase is the default of the Status Phase, one of Phases, the Phase has no default when empty
+	DefaultPhase string
+	// StorageVersion marks the version as the one the API server persists, e.g. the conversion hub
+	StorageVersion bool
+	// Scale adds the Spec.Replicas, Status.Replicas and Status.Selector fields exposed by the scale subresource
+	Scale bool
+
 	Force bool
 }
 
@@ -47,7 +68,6 @@ func (f *Types) SetTemplateDefaults() error {
 	}
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
-	fmt.Println(f.Path)
 
 	f.TemplateBody = typesTemplate
 
@@ -60,6 +80,25 @@ func (f *Types) SetTemplateDefaults() error {
 	return nil
 }
 
+// ResourceMarkerArgs returns the arguments of the +kubebuilder:resource marker, empty when
+// the defaults derived from the kind are kept
+func (f *Types) ResourceMarkerArgs() string {
+	var args []string
+	if !f.Resource.IsRegularPlural() {
+		args = append(args, "path="+f.Resource.Plural)
+	}
+	if !f.Resource.API.Namespaced {
+		args = append(args, "scope=Cluster")
+	}
+	if len(f.Categories) != 0 {
+		args = append(args, "categories="+strings.Join(f.Categories, ";"))
+	}
+	if len(f.ShortNames) != 0 {
+		args = append(args, "shortName="+strings.Join(f.ShortNames, ";"))
+	}
+	return strings.Join(args, ",")
+}
+
 const typesTemplate = `{{ .Boilerplate }}
 
 package {{ .Resource.Version }}
@@ -75,10 +114,22 @@ import (
 type {{ .Resource.Kind }}Spec struct {
 	// INSERT ADDITIONAL SPEC FIELDS - desired state of cluster
 	// Important: Run "make" to regenerate code after modifying this file
+{{- if not .Minimal }}
 
 	// Foo is an example field of {{ .Resource.Kind }}. Edit {{ lower .Resource.Kind }}_types.go to remove/update
 	Foo string ` + "`" + `json:"foo,omitempty"` + "`" + `
+{{- end }}
+{{- if .Scale }}
+
+	// Replicas is the desired number of replicas of {{ .Resource.Kind }}, set by "kubectl scale" and the
+	// HorizontalPodAutoscaler through the scale subresource.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default:=1
+	//+optional
+	Replicas *int32 ` + "`" + `json:"replicas,omitempty"` + "`" + `
+{{- end }}
 }
+{{- if not .Minimal }}
 
 type {{ .Resource.Kind }}Phase string
 
@@ -88,12 +139,32 @@ const (
 	{{ .Resource.Kind }}Unknown {{ .Resource.Kind }}Phase = "Unknown"
 	{{ .Resource.Kind }}Active  {{ .Resource.Kind }}Phase = "Active"
 )
+{{- end }}
+{{- if not .SkipFinalizer }}
+
+// {{ .Resource.Kind }}Finalizer is the finalizer the controller sets on a {{ .Resource.Kind }} to clean up
+// its external dependencies before it is deleted, e.g. with
+// controllerutil.AddFinalizer and controllerutil.RemoveFinalizer from
+// sigs.k8s.io/controller-runtime/pkg/controller/controllerutil.
+const {{ .Resource.Kind }}Finalizer = "{{ .Resource.QualifiedGroup }}/{{ lower .Resource.Kind }}-finalizer"
+{{- end }}
 
 // {{ .Resource.Kind }}Status defines the observed state of {{ .Resource.Kind }}
 type {{ .Resource.Kind }}Status struct {
+{{- if not .Minimal }}
 	// Phase represents the current phase of {{ .Resource.Kind }}.
-	//+kubebuilder:default:=Unknown
+{{- with .DefaultPhase }}
+	//+kubebuilder:default:={{ . }}
+{{- end }}
 	Phase {{ .Resource.Kind }}Phase ` + "`" + `json:"phase,omitempty"` + "`" + `
+{{- end }}
+{{- if .Scale }}
+	// Replicas is the observed number of replicas of {{ .Resource.Kind }}.
+	Replicas int32 ` + "`" + `json:"replicas,omitempty"` + "`" + `
+	// Selector is the label selector of the pods counted in Replicas, in the string form of
+	// metav1.FormatLabelSelector, used by the HorizontalPodAutoscaler to read their metrics.
+	Selector string ` + "`" + `json:"selector,omitempty"` + "`" + `
+{{- end }}
 	// Represents the observations of a {{ .Resource.Kind }}'s current state.
 	// {{ .Resource.Kind }}.status.conditions.type are: "Available", "Progressing", and "Degraded"
 	// {{ .Resource.Kind }}.status.conditions.status are one of True, False, Unknown.
@@ -108,12 +179,20 @@ type {{ .Resource.Kind }}Status struct {
 
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
-{{- if and (not .Resource.API.Namespaced) (not .Resource.IsRegularPlural) }}
-//+kubebuilder:resource:path={{ .Resource.Plural }},scope=Cluster
-{{- else if not .Resource.API.Namespaced }}
-//+kubebuilder:resource:scope=Cluster
-{{- else if not .Resource.IsRegularPlural }}
-//+kubebuilder:resource:path={{ .Resource.Plural }}
+{{- if .Scale }}
+//+kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
+{{- end }}
+{{- if .StorageVersion }}
+//+kubebuilder:storageversion
+{{- end }}
+{{- if not .SkipPrintColumns }}
+{{- if not .Minimal }}
+//+kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+{{- end }}
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+{{- end }}
+{{- with .ResourceMarkerArgs }}
+//+kubebuilder:resource:{{ . }}
 {{- end }}
 
 // {{ .Resource.Kind }} is the Schema for the {{ .Resource.Plural }} API
@@ -17,7 +17,6 @@ limitations under the License.
 package api
 
 import (
-	"fmt"
 	"path/filepath"
 	"strings"
 
@@ -39,6 +38,12 @@ type Webhook struct { // nolint:maligned
 	// Define value for AdmissionReviewVersions marker
 	AdmissionReviewVersions string
 
+	// DryRunGuard indicates whether the defaulting and validating handlers are scaffolded as
+	// webhook.CustomDefaulter and webhook.CustomValidator skipping their side effects on dry-run requests
+	DryRunGuard bool
+	// Define value for the SideEffects marker, NoneOnDryRun when the handlers are guarded
+	SideEffects string
+
 	Force bool
 }
 
@@ -53,14 +58,28 @@ func (f *Webhook) SetTemplateDefaults() error {
 	}
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
-	fmt.Println(f.Path)
+
+	// Conversion webhooks are served by controller-runtime, there is no handler to guard
+	f.DryRunGuard = f.DryRunGuard && (f.Resource.HasDefaultingWebhook() || f.Resource.HasValidationWebhook())
+	f.SideEffects = "None"
+	if f.DryRunGuard {
+		f.SideEffects = "NoneOnDryRun"
+	}
 
 	webhookTemplate := webhookTemplate
 	if f.Resource.HasDefaultingWebhook() {
-		webhookTemplate = webhookTemplate + defaultingWebhookTemplate
+		if f.DryRunGuard {
+			webhookTemplate = webhookTemplate + customDefaultingWebhookTemplate
+		} else {
+			webhookTemplate = webhookTemplate + defaultingWebhookTemplate
+		}
 	}
 	if f.Resource.HasValidationWebhook() {
-		webhookTemplate = webhookTemplate + validatingWebhookTemplate
+		if f.DryRunGuard {
+			webhookTemplate = webhookTemplate + customValidatingWebhookTemplate
+		} else {
+			webhookTemplate = webhookTemplate + validatingWebhookTemplate
+		}
 	}
 	f.TemplateBody = webhookTemplate
 
@@ -82,9 +101,14 @@ const (
 package {{ .Resource.Version }}
 
 import (
+	{{- if .DryRunGuard }}
+	"context"
+	"fmt"
+
+	{{- end }}
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
-	{{- if .Resource.HasValidationWebhook }}
+	{{- if or .Resource.HasValidationWebhook .DryRunGuard }}
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 	{{- end }}
@@ -100,15 +124,34 @@ var {{ lower .Resource.Kind }}log = logf.Log.WithName("{{ lower .Resource.Kind }
 func (r *{{ .Resource.Kind }}) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		{{- if and .DryRunGuard .Resource.HasDefaultingWebhook }}
+		WithDefaulter(&{{ .Resource.Kind }}CustomDefaulter{}).
+		{{- end }}
+		{{- if and .DryRunGuard .Resource.HasValidationWebhook }}
+		WithValidator(&{{ .Resource.Kind }}CustomValidator{}).
+		{{- end }}
 		Complete()
 }
+{{- if .DryRunGuard }}
+
+// is{{ .Resource.Kind }}DryRun returns whether the admission request handled in ctx is a dry-run one,
+// nothing is persisted then so the handlers, registered with sideEffects=NoneOnDryRun, must skip
+// their side effects, e.g. calls to external systems.
+func is{{ .Resource.Kind }}DryRun(ctx context.Context) (bool, error) {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	return req.DryRun != nil && *req.DryRun, nil
+}
+{{- end }}
 
 // TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 `
 
 	//nolint:lll
 	defaultingWebhookTemplate = `
-//+kubebuilder4helm:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/mutate-{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=true,failurePolicy=fail,sideEffects=None,groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=m{{ lower .Resource.Kind }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
+//+kubebuilder4helm:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/mutate-{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=true,failurePolicy=fail,sideEffects={{ .SideEffects }},groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=m{{ lower .Resource.Kind }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
 
 var _ webhook.Defaulter = &{{ .Resource.Kind }}{}
 
@@ -123,7 +166,7 @@ func (r *{{ .Resource.Kind }}) Default() {
 	//nolint:lll
 	validatingWebhookTemplate = `
 // TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
-//+kubebuilder4helm:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/validate-{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=false,failurePolicy=fail,sideEffects=None,groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=v{{ lower .Resource.Kind }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
+//+kubebuilder4helm:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/validate-{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=false,failurePolicy=fail,sideEffects={{ .SideEffects }},groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=v{{ lower .Resource.Kind }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
 
 var _ webhook.Validator = &{{ .Resource.Kind }}{}
 
@@ -150,5 +193,96 @@ func (r *{{ .Resource.Kind }}) ValidateDelete() (admission.Warnings, error) {
 	// TODO(user): fill in your validation logic upon object deletion.
 	return nil,nil
 }
+`
+
+	//nolint:lll
+	customDefaultingWebhookTemplate = `
+//+kubebuilder4helm:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/mutate-{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=true,failurePolicy=fail,sideEffects={{ .SideEffects }},groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=m{{ lower .Resource.Kind }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
+
+// {{ .Resource.Kind }}CustomDefaulter defaults the {{ .Resource.Kind }} objects, unlike webhook.Defaulter it is
+// handed the admission request through the context to tell dry-run requests apart.
+type {{ .Resource.Kind }}CustomDefaulter struct{}
+
+var _ webhook.CustomDefaulter = &{{ .Resource.Kind }}CustomDefaulter{}
+
+// Default implements webhook.CustomDefaulter so a webhook will be registered for the type
+func (d *{{ .Resource.Kind }}CustomDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	r, ok := obj.(*{{ .Resource.Kind }})
+	if !ok {
+		return fmt.Errorf("expected a {{ .Resource.Kind }} object but got %T", obj)
+	}
+	{{ lower .Resource.Kind }}log.Info("default", "name", r.Name)
+
+	// TODO(user): fill in your defaulting logic.
+
+	if dryRun, err := is{{ .Resource.Kind }}DryRun(ctx); err != nil || dryRun {
+		return err
+	}
+	// TODO(user): fill in the side effects of the defaulting, they are skipped on dry-run requests.
+	return nil
+}
+`
+
+	//nolint:lll
+	customValidatingWebhookTemplate = `
+// TODO(user): change verbs to "verbs=create;update;delete" if you want to enable deletion validation.
+//+kubebuilder4helm:webhook:{{ if ne .Resource.Webhooks.WebhookVersion "v1" }}webhookVersions={{"{"}}{{ .Resource.Webhooks.WebhookVersion }}{{"}"}},{{ end }}path=/validate-{{ .QualifiedGroupWithDash }}-{{ .Resource.Version }}-{{ lower .Resource.Kind }},mutating=false,failurePolicy=fail,sideEffects={{ .SideEffects }},groups={{ .Resource.QualifiedGroup }},resources={{ .Resource.Plural }},verbs=create;update,versions={{ .Resource.Version }},name=v{{ lower .Resource.Kind }}.kb.io,admissionReviewVersions={{ .AdmissionReviewVersions }}
+
+// {{ .Resource.Kind }}CustomValidator validates the {{ .Resource.Kind }} objects, unlike webhook.Validator it is
+// handed the admission request through the context to tell dry-run requests apart.
+type {{ .Resource.Kind }}CustomValidator struct{}
+
+var _ webhook.CustomValidator = &{{ .Resource.Kind }}CustomValidator{}
+
+// ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *{{ .Resource.Kind }}CustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	r, ok := obj.(*{{ .Resource.Kind }})
+	if !ok {
+		return nil, fmt.Errorf("expected a {{ .Resource.Kind }} object but got %T", obj)
+	}
+	{{ lower .Resource.Kind }}log.Info("validate create", "name", r.Name)
+
+	// TODO(user): fill in your validation logic upon object creation.
+
+	if dryRun, err := is{{ .Resource.Kind }}DryRun(ctx); err != nil || dryRun {
+		return nil, err
+	}
+	// TODO(user): fill in the side effects of the validation, they are skipped on dry-run requests.
+	return nil, nil
+}
+
+// ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *{{ .Resource.Kind }}CustomValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	r, ok := newObj.(*{{ .Resource.Kind }})
+	if !ok {
+		return nil, fmt.Errorf("expected a {{ .Resource.Kind }} object but got %T", newObj)
+	}
+	{{ lower .Resource.Kind }}log.Info("validate update", "name", r.Name)
+
+	// TODO(user): fill in your validation logic upon object update.
+
+	if dryRun, err := is{{ .Resource.Kind }}DryRun(ctx); err != nil || dryRun {
+		return nil, err
+	}
+	// TODO(user): fill in the side effects of the validation, they are skipped on dry-run requests.
+	return nil, nil
+}
+
+// ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type
+func (v *{{ .Resource.Kind }}CustomValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	r, ok := obj.(*{{ .Resource.Kind }})
+	if !ok {
+		return nil, fmt.Errorf("expected a {{ .Resource.Kind }} object but got %T", obj)
+	}
+	{{ lower .Resource.Kind }}log.Info("validate delete", "name", r.Name)
+
+	// TODO(user): fill in your validation logic upon object deletion.
+
+	if dryRun, err := is{{ .Resource.Kind }}DryRun(ctx); err != nil || dryRun {
+		return nil, err
+	}
+	// TODO(user): fill in the side effects of the validation, they are skipped on dry-run requests.
+	return nil, nil
+}
 `
 )
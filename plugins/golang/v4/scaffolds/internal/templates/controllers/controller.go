@@ -17,7 +17,6 @@ limitations under the License.
 package controllers
 
 import (
-	"fmt"
 	"path/filepath"
 
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
@@ -61,7 +60,6 @@ func (f *Controller) SetTemplateDefaults() error {
 	}
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
-	fmt.Println(f.Path)
 
 	f.TemplateBody = controllerTemplate
 	f.PackageName = "controller"
@@ -113,6 +111,8 @@ type {{ .Resource.Kind }}Reconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
 	Recorder record.EventRecorder
+	// MaxConcurrentReconciles overrides the --default-concurrent flag when set
+	MaxConcurrentReconciles int
 	// For more details
 	// - https://github.com/labring/operator-sdk/blob/{{ .EndpointOperatorLibVersion }}/controller/finalizer.go
 	finalizer *controller.Finalizer
@@ -210,6 +210,10 @@ func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager,opts
 	if r.Recorder == nil {
 		r.Recorder = mgr.GetEventRecorderFor("{{ lower .Resource.Kind }}-controller")
 	}
+	concurrent := controller.GetConcurrent(opts)
+	if r.MaxConcurrentReconciles > 0 {
+		concurrent = r.MaxConcurrentReconciles
+	}
 	return ctrl.NewControllerManagedBy(mgr).
 		{{ if not (isEmptyStr .Resource.Path) -}}
 		For(&{{ .Resource.ImportAlias }}.{{ .Resource.Kind }}{}).
@@ -218,7 +222,7 @@ func (r *{{ .Resource.Kind }}Reconciler) SetupWithManager(mgr ctrl.Manager,opts
 		// For().
 		{{- end }}
 		WithOptions(kubecontroller.Options{
-			MaxConcurrentReconciles: controller.GetConcurrent(opts),
+			MaxConcurrentReconciles: concurrent,
 			RateLimiter:             controller.GetRateLimiter(opts),
 		}).
 		Complete(r)
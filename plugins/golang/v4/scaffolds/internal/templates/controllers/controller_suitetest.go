@@ -61,7 +61,6 @@ func (f *SuiteTest) SetTemplateDefaults() error {
 	}
 
 	f.Path = f.Resource.Replacer().Replace(f.Path)
-	fmt.Println(f.Path)
 	f.PackageName = "controller"
 	if f.IsLegacyLayout {
 		f.PackageName = "controllers"
@@ -41,12 +41,14 @@ func (f *Dockerfile) SetTemplateDefaults() error {
 	if f.IsLegacyLayout {
 		fmtSource = `COPY main.go main.go
 COPY api/ api/
-COPY controllers/ controllers/`
+COPY controllers/ controllers/
+COPY pkg/feature/ pkg/feature/`
 		maingo = defaultLegacyLayoutMainPath
 	} else {
 		fmtSource = `COPY cmd/main.go cmd/main.go
 COPY api/ api/
-COPY internal/controller/ internal/controller/`
+COPY internal/controller/ internal/controller/
+COPY internal/feature/ internal/feature/`
 		maingo = defaultMainPath
 	}
 	f.TemplateBody = fmt.Sprintf(dockerfileTemplate, fmtSource, maingo)
@@ -0,0 +1,122 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+// featureGatesDir returns the directory of the package holding the feature gates, relative to the repository
+func featureGatesDir(isLegacyLayout bool) string {
+	if isLegacyLayout {
+		return path.Join("pkg", "feature")
+	}
+	return path.Join("internal", "feature")
+}
+
+var _ machinery.Template = &FeatureGates{}
+
+// FeatureGates scaffolds the package holding the feature gates set with the --feature-gates flag
+type FeatureGates struct {
+	machinery.TemplateMixin
+	machinery.BoilerplateMixin
+	// IsLegacyLayout is added to ensure backwards compatibility and should
+	// be removed when we remove the go/v3 plugin
+	IsLegacyLayout bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *FeatureGates) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(filepath.FromSlash(featureGatesDir(f.IsLegacyLayout)), "gates.go")
+	}
+
+	f.TemplateBody = featureGatesTemplate
+
+	return nil
+}
+
+const featureGatesTemplate = `{{ .Boilerplate }}
+
+// Package feature holds the feature gates toggling alpha and beta behaviors of the manager.
+package feature
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Gates holds the feature gates set with --feature-gates keyed by name, the ones not set are disabled.
+var Gates = map[string]bool{}
+
+// Enabled reports whether the feature gate name is enabled.
+func Enabled(name string) bool {
+	return Gates[name]
+}
+
+// Parse parses a comma-separated list of name=true|false pairs, e.g. "Foo=true,Bar=false".
+func Parse(value string) (map[string]bool, error) {
+	gates := map[string]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, enabled, ok := strings.Cut(entry, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid feature gate %q, expected name=true|false", entry)
+		}
+		b, err := strconv.ParseBool(strings.TrimSpace(enabled))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q of feature gate %q, expected true or false", enabled, name)
+		}
+		gates[name] = b
+	}
+	return gates, nil
+}
+
+// Flag implements flag.Value, setting Gates from a list parsed with Parse.
+type Flag struct{}
+
+// String implements flag.Value
+func (Flag) String() string {
+	pairs := make([]string, 0, len(Gates))
+	for name, enabled := range Gates {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", name, enabled))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+// Set implements flag.Value
+func (Flag) Set(value string) error {
+	gates, err := Parse(value)
+	if err != nil {
+		return err
+	}
+	for name, enabled := range gates {
+		Gates[name] = enabled
+	}
+	return nil
+}
+`
@@ -29,6 +29,8 @@ type GoMod struct {
 
 	ControllerRuntimeVersion   string
 	EndpointOperatorLibVersion string
+	// OpenTelemetryVersion pins the OpenTelemetry modules, it is only set when tracing is scaffolded
+	OpenTelemetryVersion string
 }
 
 // SetTemplateDefaults implements file.Template
@@ -52,5 +54,10 @@ go 1.20
 require (
 	sigs.k8s.io/controller-runtime {{ .ControllerRuntimeVersion }}
 	github.com/labring/operator-sdk {{ .EndpointOperatorLibVersion }}
+{{- if .OpenTelemetryVersion }}
+	go.opentelemetry.io/otel {{ .OpenTelemetryVersion }}
+	go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc {{ .OpenTelemetryVersion }}
+	go.opentelemetry.io/otel/sdk {{ .OpenTelemetryVersion }}
+{{- end }}
 )
 `
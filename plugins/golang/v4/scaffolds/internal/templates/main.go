@@ -18,8 +18,10 @@ package templates
 
 import (
 	"fmt"
+	"path"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
@@ -38,6 +40,26 @@ type Main struct {
 	// IsLegacyLayout is added to ensure backwards compatibility and should
 	// be removed when we remove the go/v3 plugin
 	IsLegacyLayout bool
+	// Tracing indicates whether the OpenTelemetry tracing bootstrap should be scaffolded
+	Tracing bool
+	// FeatureGatesPackage is the import path of the package holding the feature gates
+	FeatureGatesPackage string
+	// LeaderElectionID is the default name of the leader election Lease, derived from the
+	// repository and the domain when empty
+	LeaderElectionID string
+	// LeaderElectionReleaseOnCancel indicates whether the leader steps down voluntarily when the manager ends
+	LeaderElectionReleaseOnCancel bool
+
+	// MinRetryDelay, MaxRetryDelay and Burst seed the defaults of the rate limiter flags
+	MinRetryDelay time.Duration
+	MaxRetryDelay time.Duration
+	Burst         int
+
+	// ExtraSchemes are the external API packages registered into the manager scheme
+	ExtraSchemes []SchemeImport
+	// HealthProbePathPrefix prefixes the /healthz and /readyz paths the probes are served on,
+	// it must match the probe paths of the chart
+	HealthProbePathPrefix string
 }
 
 // SetTemplateDefaults implements file.Template
@@ -49,16 +71,27 @@ func (f *Main) SetTemplateDefaults() error {
 			f.Path = filepath.Join(defaultMainPath)
 		}
 	}
+	f.FeatureGatesPackage = path.Join(f.Repo, featureGatesDir(f.IsLegacyLayout))
 
 	f.TemplateBody = fmt.Sprintf(mainTemplate,
 		machinery.NewMarkerFor(f.Path, importMarker),
 		machinery.NewMarkerFor(f.Path, addSchemeMarker),
+		machinery.NewMarkerFor(f.Path, flagsMarker),
 		machinery.NewMarkerFor(f.Path, setupMarker),
 	)
 
 	return nil
 }
 
+// SchemeImport is an external API package, e.g. of another operator, whose types are registered
+// into the manager scheme with its AddToScheme function
+type SchemeImport struct {
+	// Path is the import path of the package, e.g. github.com/cert-manager/cert-manager/pkg/apis/certmanager/v1
+	Path string
+	// Alias is the name the package is imported as, e.g. certmanagerv1
+	Alias string
+}
+
 var _ machinery.Inserter = &MainUpdater{}
 
 // MainUpdater updates cmd/main.go to run Controllers
@@ -69,6 +102,10 @@ type MainUpdater struct { //nolint:maligned
 
 	// Flags to indicate which parts need to be included when updating the file
 	WireResource, WireController, WireWebhook bool
+	// WireMetrics registers the custom collectors of the controller in the metrics registry of the manager
+	WireMetrics bool
+	// ExtraSchemes are the external API packages to register into the manager scheme
+	ExtraSchemes []SchemeImport
 	// IsLegacyLayout is added to ensure backwards compatibility and should
 	// be removed when we remove the go/v3 plugin
 	IsLegacyLayout bool
@@ -91,6 +128,7 @@ func (*MainUpdater) GetIfExistsAction() machinery.IfExistsAction {
 const (
 	importMarker    = "imports"
 	addSchemeMarker = "scheme"
+	flagsMarker     = "flags"
 	setupMarker     = "builder"
 )
 
@@ -99,6 +137,7 @@ func (f *MainUpdater) GetMarkers() []machinery.Marker {
 	return []machinery.Marker{
 		machinery.NewMarkerFor(f.GetPath(), importMarker),
 		machinery.NewMarkerFor(f.GetPath(), addSchemeMarker),
+		machinery.NewMarkerFor(f.GetPath(), flagsMarker),
 		machinery.NewMarkerFor(f.GetPath(), setupMarker),
 	}
 }
@@ -112,38 +151,69 @@ const (
 `
 	addschemeCodeFragment = `utilruntime.Must(%s.AddToScheme(scheme))
 `
-	reconcilerSetupCodeFragment = `if err = (&controller.%sReconciler{}).SetupWithManager(mgr, rateLimiterOptions); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "%s")
-		os.Exit(1)
+	ctrlMetricsImportCodeFragment = `ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+`
+	metricsImportCodeFragment = `"%s"
+`
+	multiGroupMetricsImportCodeFragment = `%smetrics "%s"
+`
+	metricsRegisterCodeFragment = `ctrlmetrics.Registry.MustRegister(%s.%sCollectors...)
+`
+	concurrentFlagCodeFragment = `%sConcurrent := flag.Int("%s-concurrent", 0,
+		"The number of concurrent %s reconciles, defaults to --default-concurrent.")
+`
+	reconcilerSetupCodeFragment = `if !disableControllers {
+		if err = (&controller.%sReconciler{
+			MaxConcurrentReconciles: *%sConcurrent,
+		}).SetupWithManager(mgr, rateLimiterOptions); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "%s")
+			os.Exit(1)
+		}
 	}
 `
-	multiGroupReconcilerSetupCodeFragment = `if err = (&%scontroller.%sReconciler{}).SetupWithManager(mgr, rateLimiterOptions); err != nil {
-		setupLog.Error(err, "unable to create controller", "controller", "%s")
-		os.Exit(1)
+	multiGroupReconcilerSetupCodeFragment = `if !disableControllers {
+		if err = (&%scontroller.%sReconciler{
+			MaxConcurrentReconciles: *%sConcurrent,
+		}).SetupWithManager(mgr, rateLimiterOptions); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "%s")
+			os.Exit(1)
+		}
 	}
 `
-	webhookSetupCodeFragment = `if os.Getenv("DISABLE_WEBHOOKS") != "true" {
+	webhookSetupCodeFragment = `if !disableWebhooks {
 		if err = (&%s.%s{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "%s")
 			os.Exit(1)
-		}else{
-			setupLog.Info("webhook disable", "webhook", "%s")
 		}
+	} else {
+		setupLog.Info("webhook disabled", "webhook", "%s")
 	}
 `
 )
 
 // GetCodeFragments implements file.Inserter
 func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
-	fragments := make(machinery.CodeFragmentsMap, 3)
+	fragments := make(machinery.CodeFragmentsMap, 4)
+
+	// Generate the import and add scheme code fragments of the external API packages,
+	// the ones already registered are filtered out when inserting them
+	imports := make([]string, 0)
+	addScheme := make([]string, 0)
+	for _, extra := range f.ExtraSchemes {
+		imports = append(imports, fmt.Sprintf(apiImportCodeFragment, extra.Alias, extra.Path))
+		addScheme = append(addScheme, fmt.Sprintf(addschemeCodeFragment, extra.Alias))
+	}
 
 	// If resource is not being provided we are creating the file, not updating it
 	if f.Resource == nil {
+		if len(imports) != 0 {
+			fragments[machinery.NewMarkerFor(f.GetPath(), importMarker)] = imports
+			fragments[machinery.NewMarkerFor(f.GetPath(), addSchemeMarker)] = addScheme
+		}
 		return fragments
 	}
 
 	// Generate import code fragments
-	imports := make([]string, 0)
 	if f.WireResource {
 		imports = append(imports, fmt.Sprintf(apiImportCodeFragment, f.Resource.ImportAlias(), f.Resource.Path))
 	}
@@ -166,22 +236,48 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 		}
 	}
 
+	if f.WireMetrics {
+		imports = append(imports, ctrlMetricsImportCodeFragment)
+		if !f.MultiGroup || f.Resource.Group == "" {
+			imports = append(imports, fmt.Sprintf(metricsImportCodeFragment, path.Join(f.Repo, metricsDir(f.IsLegacyLayout))))
+		} else {
+			imports = append(imports, fmt.Sprintf(multiGroupMetricsImportCodeFragment,
+				f.Resource.PackageName(), path.Join(f.Repo, metricsDir(f.IsLegacyLayout), f.Resource.Group)))
+		}
+	}
+
 	// Generate add scheme code fragments
-	addScheme := make([]string, 0)
 	if f.WireResource {
 		addScheme = append(addScheme, fmt.Sprintf(addschemeCodeFragment, f.Resource.ImportAlias()))
 	}
 
-	// Generate setup code fragments
+	// Generate the per controller concurrency flag and setup code fragments,
+	// multi-group projects prefix them with the group as kinds may be repeated
+	flags := make([]string, 0)
 	setup := make([]string, 0)
 	if f.WireController {
+		concurrentVar, concurrentFlag := strings.ToLower(f.Resource.Kind), strings.ToLower(f.Resource.Kind)
+		if f.MultiGroup && f.Resource.Group != "" {
+			concurrentVar = f.Resource.PackageName() + f.Resource.Kind
+			concurrentFlag = strings.ToLower(f.Resource.Group) + "-" + concurrentFlag
+		}
+		flags = append(flags, fmt.Sprintf(concurrentFlagCodeFragment,
+			concurrentVar, concurrentFlag, f.Resource.Kind))
+
 		if !f.MultiGroup || f.Resource.Group == "" {
 			setup = append(setup, fmt.Sprintf(reconcilerSetupCodeFragment,
-				f.Resource.Kind, f.Resource.Kind))
+				f.Resource.Kind, concurrentVar, f.Resource.Kind))
 		} else {
 			setup = append(setup, fmt.Sprintf(multiGroupReconcilerSetupCodeFragment,
-				f.Resource.PackageName(), f.Resource.Kind, f.Resource.Kind))
+				f.Resource.PackageName(), f.Resource.Kind, concurrentVar, f.Resource.Kind))
+		}
+	}
+	if f.WireMetrics {
+		metricsPackage := "metrics"
+		if f.MultiGroup && f.Resource.Group != "" {
+			metricsPackage = f.Resource.PackageName() + "metrics"
 		}
+		setup = append(setup, fmt.Sprintf(metricsRegisterCodeFragment, metricsPackage, f.Resource.Kind))
 	}
 	if f.WireWebhook {
 		setup = append(setup, fmt.Sprintf(webhookSetupCodeFragment,
@@ -195,6 +291,9 @@ func (f *MainUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
 	if len(addScheme) != 0 {
 		fragments[machinery.NewMarkerFor(f.GetPath(), addSchemeMarker)] = addScheme
 	}
+	if len(flags) != 0 {
+		fragments[machinery.NewMarkerFor(f.GetPath(), flagsMarker)] = flags
+	}
 	if len(setup) != 0 {
 		fragments[machinery.NewMarkerFor(f.GetPath(), setupMarker)] = setup
 	}
@@ -207,8 +306,19 @@ var mainTemplate = `{{ .Boilerplate }}
 package main
 
 import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"flag"
+	"fmt"
+	"net"
+	"net/http"
 	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -217,11 +327,27 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"go.uber.org/zap/zapcore"
+	"sigs.k8s.io/yaml"
+{{- if .Tracing }}
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+{{- end }}
+{{- range .ExtraSchemes }}
+	{{ .Alias }} "{{ .Path }}"
+{{- end }}
 	%s
 	utilcontroller "github.com/labring/operator-sdk/controller"
+	"{{ .FeatureGatesPackage }}"
 )
 
 var (
@@ -231,6 +357,9 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+{{- range .ExtraSchemes }}
+	utilruntime.Must({{ .Alias }}.AddToScheme(scheme))
+{{- end }}
 
 	%s
 }
@@ -238,31 +367,203 @@ func init() {
 func main() {
 	var (
 		metricsAddr          string
+		secureMetrics        bool
+		enableHTTP2          bool
 		enableLeaderElection bool
+		disableControllers   bool
+		disableWebhooks      bool
+		leaderElectionNamespace string
+		leaderElectionID        string
+		leaderElectionResourceLock string
 		probeAddr            string
+		pprofAddr            string
+		webhookAddr          string
+		webhookPort          int
+		gracefulShutdown     time.Duration
+		watchNamespaces      string
+		logJSON              bool
+		configFile           string
+{{- if .Tracing }}
+		tracingEndpoint      string
+{{- end }}
 		rateLimiterOptions   utilcontroller.RateLimiterOptions
 	)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
+	flag.BoolVar(&secureMetrics, "metrics-secure", false,
+		"If set, the metrics endpoint is served via HTTPS and protected by authentication and authorization. " +
+		"Leave unset to serve plain HTTP, e.g. behind the kube-rbac-proxy sidecar.")
+	flag.BoolVar(&enableHTTP2, "enable-http2", false,
+		"If set, HTTP/2 will be enabled for the metrics and webhook servers.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&pprofAddr, "pprof-bind-address", "", "The address the pprof endpoint binds to. " +
+		"Leave empty to disable profiling.")
+	flag.StringVar(&webhookAddr, "webhook-bind-address", "", "The address the webhook server binds to. " +
+		"Leave empty to listen on all interfaces.")
+	flag.IntVar(&webhookPort, "webhook-port", 9443, "The port the webhook server serves at.")
+	flag.DurationVar(&gracefulShutdown, "graceful-shutdown-timeout", 30*time.Second,
+		"The duration given to runnables to stop before the manager actually returns on stop. " +
+		"Set to 0 to disable graceful shutdown.")
+	flag.StringVar(&watchNamespaces, "watch-namespaces", "", "Comma-separated list of namespaces " +
+		"the manager watches. Leave empty to watch all namespaces.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. " +
 		"Enabling this will ensure there is only one active controller manager.")
+	flag.BoolVar(&disableControllers, "disable-controllers", false,
+		"If set, the controllers aren't started and only the webhook server runs, e.g. in a Deployment " +
+		"of its own scaled apart from the controllers. Leader election is then disabled.")
+	flag.BoolVar(&disableWebhooks, "disable-webhooks", os.Getenv("DISABLE_WEBHOOKS") == "true",
+		"If set, the webhooks aren't registered, e.g. when they are served by another Deployment. " +
+		"Defaults to true when the DISABLE_WEBHOOKS env var is \"true\".")
+	flag.StringVar(&leaderElectionID, "leader-election-id",
+		"{{ if .LeaderElectionID }}{{ .LeaderElectionID }}{{ else }}{{ hashFNV .Repo }}.{{ .Domain }}{{ end }}",
+		"Name of the leader election Lease. Set it to run several instances of the manager side by side.")
+	flag.StringVar(&leaderElectionNamespace, "leader-election-namespace", "",
+		"Namespace in which the leader election resource will be created. " +
+		"Required when running out of cluster, defaults to the namespace the manager runs in.")
+	flag.StringVar(&leaderElectionResourceLock, "leader-election-resource-lock", resourcelock.LeasesResourceLock,
+		"The type of resource object that is used for locking during leader election. " +
+		"Only 'leases' is supported since client-go v0.28 removed the endpoints and configmaps based locks.")
+{{- if .Tracing }}
+	flag.StringVar(&tracingEndpoint, "tracing-endpoint", "", "The OTLP gRPC endpoint traces are exported to. " +
+		"Falls back to the OTEL_EXPORTER_OTLP_ENDPOINT env var, tracing is disabled when neither is set.")
+{{- end }}
+	flag.BoolVar(&logJSON, "log-json", false, "Encode logs as JSON for log aggregation. " +
+		"Unless --zap-stacktrace-level is set, stacktraces are then only captured on panics.")
+	flag.StringVar(&configFile, "config", "", "Path of a YAML file mapping flag names to values, " +
+		"e.g. mounted from a ConfigMap. Flags passed on the command line take precedence.")
+	flag.Var(feature.Flag{}, "feature-gates", "Comma-separated list of name=true|false pairs " +
+		"toggling alpha and beta features, e.g. Foo=true,Bar=false. Read them with feature.Enabled.")
+
+	%s
 	rateLimiterOptions.BindFlags(flag.CommandLine)
+	// Seed the rate limiter flags with the project defaults, the command line still overrides them.
+	for name, value := range map[string]string{
+		"min-retry-delay": "{{ .MinRetryDelay }}",
+		"max-retry-delay": "{{ .MaxRetryDelay }}",
+		"default-burst":   "{{ .Burst }}",
+	} {
+		// The logger isn't set up before the flags are parsed, so report to stderr like the --config errors.
+		f := flag.Lookup(name)
+		if f == nil {
+			fmt.Fprintln(os.Stderr, "unable to set flag default: flag not registered:", name)
+			os.Exit(1)
+		}
+		if err := f.Value.Set(value); err != nil {
+			fmt.Fprintf(os.Stderr, "unable to set flag default of --%%s: %%v\n", name, err)
+			os.Exit(1)
+		}
+		f.DefValue = value
+	}
 	opts := zap.Options{
 		Development: true,
 	}
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
+	if configFile != "" {
+		if err := loadFlags(flag.CommandLine, configFile); err != nil {
+			fmt.Fprintln(os.Stderr, "unable to load --config:", err)
+			os.Exit(1)
+		}
+	}
+
+	logOpts := []zap.Opts{zap.UseFlagOptions(&opts)}
+	if logJSON {
+		if opts.StacktraceLevel == nil {
+			opts.StacktraceLevel = zapcore.PanicLevel
+		}
+		logOpts = append(logOpts, zap.JSONEncoder())
+	}
+	ctrl.SetLogger(zap.New(logOpts...))
 
-	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
+	// "0" disables the health probes, anything else must be a valid host:port.
+	if probeAddr != "0" {
+		if _, _, err := net.SplitHostPort(probeAddr); err != nil {
+			setupLog.Error(err, "invalid --health-probe-bind-address")
+			os.Exit(1)
+		}
+	}
+
+	switch leaderElectionResourceLock {
+	case resourcelock.LeasesResourceLock:
+	default:
+		setupLog.Error(fmt.Errorf("unsupported resource lock %%q", leaderElectionResourceLock),
+			"invalid --leader-election-resource-lock")
+		os.Exit(1)
+	}
+
+	// Webhook-only instances don't reconcile, so they must not hold the Lease the controllers wait on.
+	if disableControllers {
+		enableLeaderElection = false
+	}
+
+{{- if .Tracing }}
+
+	if tracingEndpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT") != "" {
+		shutdownTracing, err := setupTracing(context.Background(), tracingEndpoint)
+		if err != nil {
+			setupLog.Error(err, "unable to set up tracing")
+			os.Exit(1)
+		}
+		defer func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := shutdownTracing(ctx); err != nil {
+				setupLog.Error(err, "unable to flush traces")
+			}
+		}()
+	}
+{{- end }}
+
+	// HTTP/2 is disabled by default because of its vulnerabilities to the HTTP/2 Stream
+	// Cancellation and Rapid Reset CVEs, the servers then only negotiate HTTP/1.1.
+	// More info:
+	// - https://github.com/advisories/GHSA-qppj-fm5r-hxr3
+	// - https://github.com/advisories/GHSA-4374-p667-p6c8
+	var tlsOpts []func(*tls.Config)
+	if !enableHTTP2 {
+		tlsOpts = append(tlsOpts, func(c *tls.Config) {
+			setupLog.Info("disabling http/2")
+			c.NextProtos = []string{"http/1.1"}
+		})
+	}
+
+	metricsServerOptions := metricsserver.Options{
+		BindAddress:   metricsAddr,
+		SecureServing: secureMetrics,
+		TLSOpts:       tlsOpts,
+	}
+	if secureMetrics {
+		// The filter authenticates and authorizes the scrape requests, the manager
+		// ServiceAccount must be allowed to create TokenReviews and SubjectAccessReviews.
+		metricsServerOptions.FilterProvider = filters.WithAuthenticationAndAuthorization
+	}
 
 	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
 		Scheme:                 scheme,
-		MetricsBindAddress:     metricsAddr,
-		Port:                   9443,
+		Metrics:                metricsServerOptions,
+		WebhookServer: webhook.NewServer(webhook.Options{
+			Host:    webhookAddr,
+			Port:    webhookPort,
+			TLSOpts: tlsOpts,
+		}),
+		Cache: cache.Options{
+			DefaultNamespaces: parseNamespaces(watchNamespaces),
+		},
 		HealthProbeBindAddress: probeAddr,
+{{- if .HealthProbePathPrefix }}
+		LivenessEndpointName:   "{{ .HealthProbePathPrefix }}/healthz",
+		ReadinessEndpointName:  "{{ .HealthProbePathPrefix }}/readyz",
+{{- end }}
+		// PprofBindAddress serves the net/http/pprof handlers on a dedicated server
+		// started together with the manager; it stays disabled unless the flag is set.
+		PprofBindAddress:       pprofAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "{{ hashFNV .Repo }}.{{ .Domain }}",
+		// GracefulShutdownTimeout gives controllers (e.g. running finalizers) time to
+		// finish before the process exits during rolling updates.
+		GracefulShutdownTimeout: &gracefulShutdown,
+		LeaderElectionID:       leaderElectionID,
+		LeaderElectionNamespace: leaderElectionNamespace,
+		LeaderElectionResourceLock: leaderElectionResourceLock,
 		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
 		// when the Manager ends. This requires the binary to immediately end when the
 		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
@@ -273,7 +574,11 @@ func main() {
 		// the manager stops, so would be fine to enable this option. However, 
 		// if you are doing or is intended to do any operation such as perform cleanups 
 		// after the manager stops then its usage might be unsafe.
+{{- if .LeaderElectionReleaseOnCancel }}
+		LeaderElectionReleaseOnCancel: true,
+{{- else }}
 		// LeaderElectionReleaseOnCancel: true,
+{{- end }}
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
@@ -286,7 +591,7 @@ func main() {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
 	}
-	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+	if err := mgr.AddReadyzCheck("readyz", cacheSyncCheck(mgr)); err != nil {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
@@ -297,4 +602,92 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+{{- if .Tracing }}
+
+// setupTracing registers a global TracerProvider exporting spans over OTLP gRPC.
+// When endpoint is empty the exporter reads the OTEL_EXPORTER_OTLP_* env vars,
+// e.g. OTEL_EXPORTER_OTLP_ENDPOINT and OTEL_EXPORTER_OTLP_INSECURE.
+func setupTracing(ctx context.Context, endpoint string) (func(context.Context) error, error) {
+	var opts []otlptracegrpc.Option
+	if endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(endpoint))
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+{{- end }}
+
+// cacheSyncCheck returns a readiness check that fails until the manager cache
+// has synced its informers, so no traffic is sent to a manager that isn't primed yet.
+func cacheSyncCheck(mgr ctrl.Manager) healthz.Checker {
+	var (
+		once   sync.Once
+		synced atomic.Bool
+	)
+	return func(_ *http.Request) error {
+		once.Do(func() {
+			go func() {
+				synced.Store(mgr.GetCache().WaitForCacheSync(context.Background()))
+			}()
+		})
+		if !synced.Load() {
+			return errors.New("informer caches are not synced yet")
+		}
+		return nil
+	}
+}
+
+// parseNamespaces splits a comma-separated list of namespaces into the cache configuration
+// of each of them, dropping empty and duplicated entries. It returns nil, which means all
+// namespaces, when none are set.
+func parseNamespaces(value string) map[string]cache.Config {
+	var namespaces map[string]cache.Config
+	for _, ns := range strings.Split(value, ",") {
+		ns = strings.TrimSpace(ns)
+		if ns == "" {
+			continue
+		}
+		if namespaces == nil {
+			namespaces = map[string]cache.Config{}
+		}
+		namespaces[ns] = cache.Config{}
+	}
+	return namespaces
+}
+
+// loadFlags sets the flags of fs from the YAML file at path, which maps flag names to values.
+// Flags already set on the command line are left untouched.
+func loadFlags(fs *flag.FlagSet, path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return err
+	}
+	values := map[string]interface{}{}
+	decoder := json.NewDecoder(strings.NewReader(string(data)))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return err
+	}
+	set := map[string]bool{}
+	fs.Visit(func(f *flag.Flag) { set[f.Name] = true })
+	for name, value := range values {
+		if set[name] {
+			continue
+		}
+		if err := fs.Set(name, fmt.Sprint(value)); err != nil {
+			return fmt.Errorf("flag %%s: %%w", name, err)
+		}
+	}
+	return nil
+}
 `
@@ -36,6 +36,14 @@ type Makefile struct {
 	ControllerToolsVersion string
 	// Helm version to use in the project
 	HelmVersion string
+	// Kubeconform version to use in the project
+	KubeconformVersion string
+	// ChartName is the name of the helm chart, the packaged chart is named after it
+	ChartName string
+	// ChartDir is the directory of the helm chart, relative to the project root
+	ChartDir string
+	// CRDsDir is the chart directory the CRDs are generated into
+	CRDsDir string
 	// ControllerRuntimeVersion version to be used to download the envtest setup script
 	ControllerRuntimeVersion string
 	// EndpointOperatorLibVersion version to be used to download the envtest setup script
@@ -74,8 +82,11 @@ func (f *Makefile) SetTemplateDefaults() error {
 const makefileTemplate = `
 # Image URL to use all building/pushing image targets
 IMG ?= {{ .Image }}
+# IMG split into the main.image.repository and main.image.tag chart values, the registry may carry a port.
+IMG_REPOSITORY = $(shell echo $(IMG) | sed 's/:[^:/]*$$//')
+IMG_TAG = $(or $(shell echo $(IMG) | sed -n 's/.*:\([^:/]*\)$$/\1/p'),latest)
 # ENVTEST_K8S_VERSION refers to the version of kubebuilder assets to be downloaded by envtest binary.
-ENVTEST_K8S_VERSION = 1.27.1
+ENVTEST_K8S_VERSION = 1.28.0
 
 # Get the currently used golang install path (in GOPATH/bin, unless GOBIN is set)
 ifeq (,$(shell go env GOBIN))
@@ -119,9 +130,9 @@ help: ## Display this help.
 
 .PHONY: manifests
 manifests: controller-gen controller-gen4helm ## Generate WebhookConfiguration, ClusterRole and CustomResourceDefinition objects.
-	$(CONTROLLER_GEN) crd paths="./..." output:crd:artifacts:config=config/{{ .ProjectName }}/crds
-	$(CONTROLLER_GEN4HELM) webhook:projectName={{ .ProjectName }} paths="./..." output:webhook:artifacts:config=config/{{ .ProjectName }}/templates
-	$(CONTROLLER_GEN4HELM) rbac:projectName={{ .ProjectName }} paths="./..." output:rbac:artifacts:config=config/{{ .ProjectName }}/templates
+	$(CONTROLLER_GEN) crd paths="./..." output:crd:artifacts:config={{ .ChartDir }}/{{ .CRDsDir }}
+	$(CONTROLLER_GEN4HELM) webhook:projectName={{ .ProjectName }} paths="./..." output:webhook:artifacts:config={{ .ChartDir }}/templates
+	$(CONTROLLER_GEN4HELM) rbac:projectName={{ .ProjectName }} paths="./..." output:rbac:artifacts:config={{ .ChartDir }}/templates
 
 .PHONY: generate
 generate: controller-gen controller-gen4helm ## Generate code containing DeepCopy, DeepCopyInto, and DeepCopyObject method implementations.
@@ -181,6 +192,32 @@ docker-buildx: test ## Build and push docker image for the manager for cross-pla
 	- $(CONTAINER_TOOL) buildx rm project-v3-builder
 	rm Dockerfile.cross
 
+.PHONY: helm-package
+helm-package: manifests helm ## Package the helm chart into bin/charts.
+	@command -v $(HELM) >/dev/null 2>&1 || { echo "helm is not installed, run make helm or set HELM to its path"; exit 1; }
+	rm -rf $(LOCALBIN)/charts
+	$(HELM) package {{ .ChartDir }} --dependency-update --destination $(LOCALBIN)/charts
+
+.PHONY: helm-lint
+helm-lint: manifests helm ## Lint the helm chart.
+	$(HELM) dependency update {{ .ChartDir }}
+	$(HELM) lint {{ .ChartDir }} --strict
+
+# CHART_VALIDATOR validates the manifests rendered by helm-template, read from its standard input
+# (i.e. make helm-template CHART_VALIDATOR="$(KUBECTL) apply --dry-run=client -f -").
+CHART_VALIDATOR ?= $(KUBECONFORM) -strict -summary -ignore-missing-schemas
+.PHONY: helm-template
+helm-template: manifests helm kubeconform ## Render the helm chart and validate the manifests with CHART_VALIDATOR.
+	$(HELM) template {{ .ProjectName }} {{ .ChartDir }} --namespace {{ .ProjectName }} --include-crds --dependency-update \
+		| $(CHART_VALIDATOR)
+
+# CHART_REGISTRY is the OCI registry the chart is pushed to (i.e. make helm-push CHART_REGISTRY=oci://ghcr.io/<org>/charts).
+# Log in to it first with helm registry login, pushing the same chart version again overwrites it.
+.PHONY: helm-push
+helm-push: helm-package ## Push the helm chart to the CHART_REGISTRY OCI registry.
+	@test -n "$(CHART_REGISTRY)" || { echo "CHART_REGISTRY is not set, i.e. make helm-push CHART_REGISTRY=oci://ghcr.io/<org>/charts"; exit 1; }
+	$(HELM) push $(LOCALBIN)/charts/{{ .ChartName }}-*.tgz $(CHART_REGISTRY)
+
 ##@ Deployment
 
 ifndef ignore-not-found
@@ -189,15 +226,16 @@ endif
 
 .PHONY: install
 install: manifests ## Install CRDs into the K8s cluster specified in ~/.kube/config.
-	$(KUBECTL) apply -f config/charts/{{ .ProjectName }}/crds
+	$(KUBECTL) apply -f {{ .ChartDir }}/{{ .CRDsDir }}
 
 .PHONY: uninstall
 uninstall: manifests ## Uninstall CRDs from the K8s cluster specified in ~/.kube/config. Call with ignore-not-found=true to ignore resource not found errors during deletion.
-	$(KUBECTL) delete --ignore-not-found=$(ignore-not-found) -f config/charts/{{ .ProjectName }}/crds
+	$(KUBECTL) delete --ignore-not-found=$(ignore-not-found) -f {{ .ChartDir }}/{{ .CRDsDir }}
 
 .PHONY: deploy
 deploy: manifests helm ## Deploy controller to the K8s cluster specified in ~/.kube/config.
-	$(HELM) upgrade --install --namespace {{ .ProjectName }} {{ .ProjectName }} config/charts/{{ .ProjectName }} --set image=${IMG}
+	$(HELM) upgrade --install --namespace {{ .ProjectName }} {{ .ProjectName }} {{ .ChartDir }} --create-namespace --dependency-update \
+		--set main.image.repository=$(IMG_REPOSITORY) --set main.image.tag=$(IMG_TAG)
 
 .PHONY: undeploy
 undeploy: ## Undeploy controller from the K8s cluster specified in ~/.kube/config. Call with ignore-not-found=true to ignore resource not found errors during deletion.
@@ -216,11 +254,13 @@ HELM ?= $(LOCALBIN)/helm
 CONTROLLER_GEN ?= $(LOCALBIN)/controller-gen
 ENVTEST ?= $(LOCALBIN)/setup-envtest
 CONTROLLER_GEN4HELM ?= $(LOCALBIN)/controller-gen4helm
+KUBECONFORM ?= $(LOCALBIN)/kubeconform
 
 ## Tool Versions
 HELM_VERSION ?= {{ .HelmVersion }}
 CONTROLLER_TOOLS_VERSION ?= {{ .ControllerToolsVersion }}
 CONTROLLER_TOOLS_VERSION4HELM ?= {{ .ControllerToolsVersion4Helm }}
+KUBECONFORM_VERSION ?= {{ .KubeconformVersion }}
 
 .PHONY: helm
 helm: $(HELM) ## Download helm locally if necessary. If wrong version is installed, it will be removed before downloading.
@@ -244,6 +284,12 @@ $(CONTROLLER_GEN): $(LOCALBIN)
 	test -s $(LOCALBIN)/controller-gen && $(LOCALBIN)/controller-gen --version | grep -q $(CONTROLLER_TOOLS_VERSION) || \
 	GOBIN=$(LOCALBIN) go install sigs.k8s.io/controller-tools/cmd/controller-gen@$(CONTROLLER_TOOLS_VERSION)
 
+.PHONY: kubeconform
+kubeconform: $(KUBECONFORM) ## Download kubeconform locally if necessary. If wrong version is installed, it will be overwritten.
+$(KUBECONFORM): $(LOCALBIN)
+	test -s $(LOCALBIN)/kubeconform && $(LOCALBIN)/kubeconform -v | grep -q $(KUBECONFORM_VERSION) || \
+	GOBIN=$(LOCALBIN) go install github.com/yannh/kubeconform/cmd/kubeconform@$(KUBECONFORM_VERSION)
+
 .PHONY: envtest
 envtest: $(ENVTEST) ## Download envtest-setup locally if necessary.
 $(ENVTEST): $(LOCALBIN)
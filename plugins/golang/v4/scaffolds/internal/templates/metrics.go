@@ -0,0 +1,95 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path"
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+// metricsDir returns the directory of the package holding the custom controller metrics, relative to the repository
+func metricsDir(isLegacyLayout bool) string {
+	if isLegacyLayout {
+		return path.Join("pkg", "metrics")
+	}
+	return path.Join("internal", "metrics")
+}
+
+var _ machinery.Template = &Metrics{}
+
+// Metrics scaffolds the file holding the custom Prometheus collectors of a controller,
+// registered in the metrics registry of the manager by main.go
+type Metrics struct {
+	machinery.TemplateMixin
+	machinery.MultiGroupMixin
+	machinery.BoilerplateMixin
+	machinery.ResourceMixin
+
+	Force bool
+	// IsLegacyLayout is added to ensure backwards compatibility and should
+	// be removed when we remove the go/v3 plugin
+	IsLegacyLayout bool
+	PackageName    string
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *Metrics) SetTemplateDefaults() error {
+	if f.Path == "" {
+		dir := filepath.FromSlash(metricsDir(f.IsLegacyLayout))
+		if f.MultiGroup && f.Resource.Group != "" {
+			f.Path = filepath.Join(dir, "%[group]", "%[kind].go")
+		} else {
+			f.Path = filepath.Join(dir, "%[kind].go")
+		}
+	}
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	f.TemplateBody = metricsTemplate
+	f.PackageName = "metrics"
+	if f.MultiGroup && f.Resource.Group != "" {
+		f.PackageName = f.Resource.PackageName()
+	}
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.Error
+	}
+
+	return nil
+}
+
+const metricsTemplate = `{{ .Boilerplate }}
+
+package {{ .PackageName }}
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// {{ .Resource.Kind }}Collectors are the custom Prometheus collectors of the {{ .Resource.Kind }} controller,
+// registered in the metrics registry of the manager by main.go.
+//
+// TODO(user): List the collectors here and update them from the Reconcile function of the controller, e.g.
+//
+//	var {{ .Resource.Kind }}Ready = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+//		Name: "{{ lower .Resource.Kind }}_ready",
+//		Help: "Whether the {{ .Resource.Kind }} is ready, by namespace and name.",
+//	}, []string{"namespace", "name"})
+var {{ .Resource.Kind }}Collectors = []prometheus.Collector{}
+`
@@ -19,6 +19,7 @@ package scaffolds
 import (
 	"fmt"
 
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"github.com/spf13/afero"
 
 	"github.com/labring/kubebuilder4helm/plugins/golang/v4/scaffolds/internal/templates"
@@ -42,15 +43,21 @@ type webhookScaffolder struct {
 	// force indicates whether to scaffold controller files even if it exists or not
 	force          bool
 	isLegacyLayout bool
+
+	// skipDryRunGuard indicates whether to scaffold the webhook handlers without skipping their side effects
+	// on dry-run requests
+	skipDryRunGuard bool
 }
 
 // NewWebhookScaffolder returns a new Scaffolder for v2 webhook creation operations
-func NewWebhookScaffolder(config config.Config, resource resource.Resource, force bool, isLegacyLayout bool) plugins.Scaffolder {
+func NewWebhookScaffolder(config config.Config, resource resource.Resource, force bool, isLegacyLayout bool,
+	skipDryRunGuard bool) plugins.Scaffolder {
 	return &webhookScaffolder{
-		config:         config,
-		resource:       resource,
-		force:          force,
-		isLegacyLayout: isLegacyLayout,
+		config:          config,
+		resource:        resource,
+		force:           force,
+		isLegacyLayout:  isLegacyLayout,
+		skipDryRunGuard: skipDryRunGuard,
 	}
 }
 
@@ -85,8 +92,8 @@ func (s *webhookScaffolder) Scaffold() error {
 		return fmt.Errorf("error updating resource: %w", err)
 	}
 
-	if err := scaffold.Execute(
-		&api.Webhook{Force: s.force},
+	if err := pluginsdk.Execute(scaffold,
+		&api.Webhook{DryRunGuard: !s.skipDryRunGuard, Force: s.force},
 		&templates.MainUpdater{WireWebhook: true, IsLegacyLayout: s.isLegacyLayout},
 	); err != nil {
 		return err
@@ -94,12 +101,13 @@ func (s *webhookScaffolder) Scaffold() error {
 
 	if doConversion {
 		fmt.Println(`Webhook server has been set up for you.
-You need to implement the conversion.Hub and conversion.Convertible interfaces for your CRD types.`)
+You need to implement the conversion.Hub and conversion.Convertible interfaces for your CRD types,
+"create api --conversion-hub-version" scaffolds them.`)
 	}
 
 	// TODO: Add test suite for conversion webhook after #1664 has been merged & conversion tests supported in envtest.
 	if doDefaulting || doValidation {
-		if err := scaffold.Execute(
+		if err := pluginsdk.Execute(scaffold,
 			&api.WebhookSuite{},
 		); err != nil {
 			return err
@@ -49,6 +49,9 @@ type createWebhookSubcommand struct {
 	// force indicates that the resource should be created even if it already exists
 	force bool
 
+	// skipDryRunGuard indicates that the webhook handlers have side effects on dry-run requests
+	skipDryRunGuard bool
+
 	// extension points for plugins to customize the scaffolding behavior
 	extConfig pluginsdk.ConfigExtension
 }
@@ -63,6 +66,11 @@ validating and/or conversion webhooks.
   # and Kind: Frigate
   %[1]s create webhook --group ship --version v1beta1 --kind Frigate --defaulting --programmatic-validation
 
+  # Create a validating webhook for Group: ship, Version: v1beta1 and Kind: Frigate whose handlers
+  # implement webhook.Validator and don't tell dry-run requests apart
+  %[1]s create webhook --group ship --version v1beta1 --kind Frigate --programmatic-validation \
+    --skip-dry-run-guard
+
   # Create conversion webhook for Group: ship, Version: v1beta1
   # and Kind: Frigate
   %[1]s create webhook --group ship --version v1beta1 --kind Frigate --conversion
@@ -81,8 +89,13 @@ func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.BoolVar(&p.options.DoConversion, "conversion", false,
 		"if set, scaffold the conversion webhook")
 
+	fs.BoolVar(&p.skipDryRunGuard, "skip-dry-run-guard", false,
+		"if set, scaffold the defaulting and validating webhooks as webhook.Defaulter and webhook.Validator "+
+			"instead of handlers skipping their side effects on dry-run requests")
+
 	fs.BoolVar(&p.force, "force", false,
 		"attempt to create resource even if it already exists")
+	pluginsdk.BindVerboseFlag(fs)
 }
 
 func (p *createWebhookSubcommand) InjectConfig(c config.Config) error {
@@ -117,7 +130,8 @@ func (p *createWebhookSubcommand) InjectResource(res *resource.Resource) error {
 }
 
 func (p *createWebhookSubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewWebhookScaffolder(p.config, *p.resource, p.force, p.extConfig.IsLegacyLayout)
+	scaffolder := scaffolds.NewWebhookScaffolder(p.config, *p.resource, p.force, p.extConfig.IsLegacyLayout,
+		p.skipDryRunGuard)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
@@ -132,7 +146,8 @@ func (p *createWebhookSubcommand) PostScaffold() error {
 	if err != nil {
 		return err
 	}
-	fmt.Print("Next: implement your new Webhook and generate the manifests with:\n$ make manifests\n")
+	fmt.Print("Next: implement your new Webhook and generate the manifests, which include the " +
+		"Mutating and ValidatingWebhookConfigurations of the chart, with:\n$ make manifests\n")
 
 	return nil
 }
@@ -32,7 +32,11 @@ func (p *createAPISubcommand) Scaffold(fs machinery.Filesystem) error {
 	if err := p.configure(); err != nil {
 		return err
 	}
-	scaffolder := scaffolds.NewAPIScaffolder(p.config, *p.resource, p.force)
+	chartDir, err := ChartDir(p.config)
+	if err != nil {
+		return err
+	}
+	scaffolder := scaffolds.NewAPIScaffolder(p.config, *p.resource, p.force, chartDir)
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
@@ -21,6 +21,7 @@ import (
 
 	"github.com/spf13/pflag"
 
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/model/resource"
 )
@@ -35,7 +36,10 @@ type createSubcommand struct {
 	force bool
 }
 
-func (p *createSubcommand) BindFlags(fs *pflag.FlagSet) { p.flagSet = fs }
+func (p *createSubcommand) BindFlags(fs *pflag.FlagSet) {
+	p.flagSet = fs
+	pluginsdk.BindVerboseFlag(fs)
+}
 
 func (p *createSubcommand) InjectConfig(c config.Config) error {
 	p.config = c
@@ -0,0 +1,104 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v3
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds"
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v3/pkg/plugin"
+)
+
+var _ plugin.EditSubcommand = &editSubcommand{}
+
+type editSubcommand struct {
+	config config.Config
+
+	// regenerate re-derives the templates of the chart from the PROJECT file
+	regenerate bool
+
+	// force overwrites the templates users may edit when regenerating
+	force bool
+}
+
+func (p *editSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
+	subcmdMeta.Description = `Regenerate the helm chart from the PROJECT file with --regenerate, e.g. after upgrading
+the plugin or when templates were deleted by mistake.
+
+Always overwritten:
+  - templates/_helpers.tpl, templates/NOTES.txt and the manager templates (deployment, rbac, metrics,
+    autoscaling, network policy, CRDs, ...)
+  - the cleanup job of the webhook configurations
+
+Only overwritten with --force, created when missing otherwise:
+  - the manager and aggregated ClusterRoles, the rules of the resources being inserted again
+  - the webhook service, deployment, ingress, certificate and conversion templates
+
+Never overwritten, as they hold the configuration of the chart:
+  - Chart.yaml, values.yaml, values.schema.json and .helmignore
+`
+	subcmdMeta.Examples = fmt.Sprintf(`  # Regenerate the chart templates, keeping the ones you may have edited
+  %[1]s edit --plugins=%[2]s --regenerate
+
+  # Regenerate the chart templates, overwriting the ones you may have edited
+  %[1]s edit --plugins=%[2]s --regenerate --force
+`, cliMeta.CommandName, pluginKey)
+}
+
+func (p *editSubcommand) BindFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&p.regenerate, "regenerate", false, "regenerate the templates of the helm chart from the PROJECT file, "+
+		"values.yaml and values.schema.json are preserved")
+	fs.BoolVar(&p.force, "force", false, "with --regenerate, also overwrite the templates you may have edited")
+	pluginsdk.BindVerboseFlag(fs)
+}
+
+func (p *editSubcommand) InjectConfig(c config.Config) error {
+	p.config = c
+
+	return nil
+}
+
+func (p *editSubcommand) Scaffold(fs machinery.Filesystem) error {
+	if !p.regenerate {
+		if p.force {
+			return fmt.Errorf("--force requires --regenerate")
+		}
+		return nil
+	}
+
+	cfg := pluginConfig{}
+	if err := p.config.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return err
+	}
+	if cfg.CertProvider == "" {
+		cfg.CertProvider = scaffolds.CertManagerProvider
+	}
+
+	chartDir, err := ChartDir(p.config)
+	if err != nil {
+		return err
+	}
+	scaffolder := scaffolds.NewEditScaffolder(p.config, p.force, cfg.initOptions(chartDir), cfg.webhookOptions())
+	scaffolder.InjectFS(fs)
+	return scaffolder.Scaffold()
+}
@@ -17,6 +17,7 @@ limitations under the License.
 package v3
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -27,6 +28,7 @@ import (
 
 	"github.com/spf13/pflag"
 
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
@@ -41,6 +43,28 @@ type initSubcommand struct {
 	// config options
 	domain string
 	name   string
+
+	// chart options
+	chartName    string
+	chartDir     string
+	chartVersion string
+	appVersion   string
+	dependencies []string
+	maintainers  []string
+	home         string
+	sources      []string
+	keywords     []string
+	metricsAuth  string
+	image        string
+	crdsMode     string
+	// prometheusRules scaffolds the PrometheusRule alerting on the manager
+	prometheusRules bool
+
+	// manager options
+	healthProbePathPrefix string
+
+	chartDependencies []scaffolds.ChartDependency
+	chartMaintainers  []scaffolds.ChartMaintainer
 }
 
 func (p *initSubcommand) UpdateMetadata(cliMeta plugin.CLIMetadata, subcmdMeta *plugin.SubcommandMetadata) {
@@ -55,12 +79,77 @@ NOTE: This plugin requires kustomize version v5 and kubectl >= 1.22.
 
   # Initialize a common project defining a specific project version
   %[1]s init --plugins common/v3 --project-version 3
+
+  # Initialize a common project whose chart is named team-operator, and scaffolded in config/team-operator,
+  # instead of after the project
+  %[1]s init --plugins common/v3 --project-name operator --chart-name team-operator
+
+  # Initialize a common project whose chart is scaffolded in deploy/chart instead of config/<project-name>
+  %[1]s init --plugins common/v3 --chart-dir deploy/chart
+
+  # Initialize a common project stamping the chart version and appVersion
+  %[1]s init --plugins common/v3 --chart-version 0.1.0 --app-version v0.1.0
+
+  # Initialize a common project whose chart is ready to be published, e.g. on Artifact Hub
+  %[1]s init --plugins common/v3 --chart-maintainer "Jane Doe:jane@example.com" --chart-maintainer "John Doe" \
+    --chart-home https://example.com/operator --chart-source https://github.com/acme/operator \
+    --chart-keyword operator
+
+  # Initialize a common project whose chart exposes the metrics without the kube-rbac-proxy sidecar
+  %[1]s init --plugins common/v3 --metrics-auth none
+
+  # Initialize a common project whose chart pulls the manager image from a known registry
+  %[1]s init --plugins common/v3 --image registry.example.com/team/operator:v0.1.0
+
+  # Initialize a common project whose chart installs the CRDs from its crds directory, before the other
+  # resources of the release, instead of rendering them as release resources upgraded with it
+  %[1]s init --plugins common/v3 --crds-mode crds-dir
+
+  # Initialize a common project whose chart can render starter alerts on the manager
+  %[1]s init --plugins common/v3 --with-prometheus-rules
+
+  # Initialize a common project whose manager serves its probes on /manager/healthz and /manager/readyz,
+  # e.g. behind a proxy routing by path
+  %[1]s init --plugins common/v3 --health-probe-path-prefix /manager
+
+  # Initialize a common project whose chart also installs redis
+  %[1]s init --plugins common/v3 --with-dependency https://charts.bitnami.com/bitnami/redis@17.0.0
 `, cliMeta.CommandName)
 }
 
 func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&p.domain, "domain", "my.domain", "domain for groups")
+	pluginsdk.BindVerboseFlag(fs)
 	fs.StringVar(&p.name, "project-name", "", "name of this project")
+	fs.StringVar(&p.chartName, "chart-name", "", "name of the helm chart, defaults to the project name")
+	fs.StringVar(&p.chartDir, "chart-dir", "", "directory the helm chart is scaffolded in, relative to the "+
+		"project root, defaults to config/<chart-name>")
+	fs.StringVar(&p.chartVersion, "chart-version", "0.0.0", "version of the helm chart, must be a semantic version")
+	fs.StringVar(&p.appVersion, "app-version", "0.0.0", "appVersion of the helm chart")
+	fs.StringArrayVar(&p.dependencies, "with-dependency", nil, "chart installed together with the helm chart "+
+		"as [<repository>/]<name>@<version>, can be repeated. Without a repository the chart is expected in "+
+		"the charts directory")
+	fs.StringArrayVar(&p.maintainers, "chart-maintainer", nil, "maintainer of the helm chart as <name>[:<email>], "+
+		"can be repeated")
+	fs.StringVar(&p.home, "chart-home", "", "URL of the project home page in the helm chart, defaults to "+
+		"https://<repo>")
+	fs.StringArrayVar(&p.sources, "chart-source", nil, "URL of the project source code in the helm chart, "+
+		"can be repeated, defaults to https://<repo>")
+	fs.StringArrayVar(&p.keywords, "chart-keyword", nil, "search keyword of the helm chart, can be repeated, "+
+		"defaults to the chart name")
+	fs.StringVar(&p.metricsAuth, "metrics-auth", scaffolds.MetricsAuthRBACProxy, "authentication of the "+
+		"metrics endpoint in the helm chart, either rbac-proxy to serve them through a kube-rbac-proxy sidecar "+
+		"or none to serve them over plain HTTP")
+	fs.StringVar(&p.image, "image", "", "default image of the manager in the helm chart values as "+
+		"[<registry>/]<repository>[:<tag>], without a tag the chart appVersion is pulled")
+	fs.StringVar(&p.crdsMode, "crds-mode", scaffolds.CRDsModeTemplates, "how the helm chart installs the CRDs "+
+		"generated by make manifests, either templates to render them as release resources upgraded with it "+
+		"or crds-dir to place them in the crds directory of the chart, which helm never upgrades nor deletes")
+	fs.BoolVar(&p.prometheusRules, "with-prometheus-rules", false, "if set, scaffold a PrometheusRule in the "+
+		"helm chart alerting when the manager is down, its reconciles fail or its leader keeps changing, "+
+		"rendered when metrics.prometheusRule.enabled is set")
+	fs.StringVar(&p.healthProbePathPrefix, "health-probe-path-prefix", "", "prefix of the /healthz and /readyz "+
+		"paths the manager serves its probes on, e.g. /manager, registered in main.go and probed by the helm chart")
 	_ = fs.MarkDeprecated("component-config", "the ComponentConfig has been deprecated in the "+
 		"Controller-Runtime since its version 0.15.0. Moreover, it has undergone breaking changes and is no longer "+
 		"functioning as intended. As a result, this tool, which heavily relies on the Controller Runtime, "+
@@ -71,6 +160,11 @@ func (p *initSubcommand) BindFlags(fs *pflag.FlagSet) {
 func (p *initSubcommand) InjectConfig(c config.Config) error {
 	p.config = c
 
+	// The domain qualifies the API groups and names the leader election Lease of the manager and the chart,
+	// so it must be a valid object name (DNS 1123 subdomain).
+	if err := validation.IsDNS1123Subdomain(p.domain); err != nil {
+		return fmt.Errorf("domain (%s) is invalid: %v", p.domain, err)
+	}
 	if err := p.config.SetDomain(p.domain); err != nil {
 		return err
 	}
@@ -91,11 +185,134 @@ func (p *initSubcommand) InjectConfig(c config.Config) error {
 		return err
 	}
 
+	// The chart name and directory are stored in the PROJECT file for the create subcommands and the Makefile.
+	cfg := pluginConfig{}
+	if p.chartName != "" && p.chartName != p.name {
+		// Check if the chart name follows the helm conventions, it names the resources of the chart by default.
+		if err := validation.IsChartName(p.chartName); err != nil {
+			return fmt.Errorf("chart name (%s) is invalid: %v", p.chartName, err)
+		}
+		cfg.ChartName = p.chartName
+	} else {
+		p.chartName = p.name
+	}
+	if p.chartDir != "" {
+		if filepath.IsAbs(p.chartDir) {
+			return fmt.Errorf("chart directory (%s) is invalid: must be relative to the project root", p.chartDir)
+		}
+		p.chartDir = filepath.ToSlash(filepath.Clean(p.chartDir))
+		if p.chartDir == "." {
+			return fmt.Errorf("chart directory (%s) is invalid: must not be the project root", p.chartDir)
+		}
+		cfg.ChartDir = p.chartDir
+	} else {
+		p.chartDir = scaffolds.DefaultChartDir(p.chartName)
+	}
+
+	// The probe path prefix is stored in the PROJECT file for the go plugin to register the probes of main.go.
+	if p.healthProbePathPrefix != "" {
+		if !strings.HasPrefix(p.healthProbePathPrefix, "/") || strings.HasSuffix(p.healthProbePathPrefix, "/") ||
+			strings.ContainsAny(p.healthProbePathPrefix, " ?#") {
+			return fmt.Errorf("health probe path prefix (%s) is invalid: must start with a / and not end with one",
+				p.healthProbePathPrefix)
+		}
+		cfg.HealthProbePathPrefix = p.healthProbePathPrefix
+	}
+	// The CRDs mode is stored in the PROJECT file for the go plugin to generate the CRDs in the matching directory.
+	switch p.crdsMode {
+	case scaffolds.CRDsModeTemplates:
+	case scaffolds.CRDsModeCRDsDir:
+		cfg.CRDsMode = p.crdsMode
+	default:
+		return fmt.Errorf("CRDs mode (%s) is invalid: must be %s or %s",
+			p.crdsMode, scaffolds.CRDsModeTemplates, scaffolds.CRDsModeCRDsDir)
+	}
+	if cfg != (pluginConfig{}) {
+		if err := p.config.EncodePluginConfig(pluginKey, cfg); err != nil {
+			return err
+		}
+	}
+
+	// Check if the chart version is a valid semantic version, helm refuses to package it otherwise.
+	if err := validation.IsSemVer(p.chartVersion); err != nil {
+		return fmt.Errorf("chart version (%s) is invalid: %v", p.chartVersion, err)
+	}
+
+	switch p.metricsAuth {
+	case scaffolds.MetricsAuthRBACProxy, scaffolds.MetricsAuthNone:
+	default:
+		return fmt.Errorf("metrics auth (%s) is invalid: must be %s or %s",
+			p.metricsAuth, scaffolds.MetricsAuthRBACProxy, scaffolds.MetricsAuthNone)
+	}
+
+	// The image is split into the repository and tag values, helm can't pull it otherwise.
+	if p.image != "" {
+		if err := validation.IsImageReference(p.image); err != nil {
+			return fmt.Errorf("image (%s) is invalid: %v", p.image, err)
+		}
+	}
+
+	// Dependency names are used as values keys and in the chart conditions.
+	for _, value := range p.dependencies {
+		dependency, err := scaffolds.ParseChartDependency(value)
+		if err != nil {
+			return fmt.Errorf("chart dependency (%s) is invalid: %v", value, err)
+		}
+		if err := validation.IsDNS1123Label(dependency.Name); err != nil {
+			return fmt.Errorf("chart dependency name (%s) is invalid: %v", dependency.Name, err)
+		}
+		p.chartDependencies = append(p.chartDependencies, dependency)
+	}
+
+	// Maintainers, home and sources are published with the chart, e.g. on Artifact Hub.
+	for _, value := range p.maintainers {
+		maintainer, err := scaffolds.ParseChartMaintainer(value)
+		if err != nil {
+			return fmt.Errorf("chart maintainer (%s) is invalid: %v", value, err)
+		}
+		p.chartMaintainers = append(p.chartMaintainers, maintainer)
+	}
+	for _, value := range append([]string{p.home}, p.sources...) {
+		if value == "" {
+			continue
+		}
+		if err := validation.IsHTTPURL(value); err != nil {
+			return fmt.Errorf("chart URL (%s) is invalid: %v", value, err)
+		}
+	}
+	for _, value := range p.keywords {
+		if strings.TrimSpace(value) == "" || strings.ContainsAny(value, "\"\n") {
+			return fmt.Errorf("chart keyword (%s) is invalid: must not be empty nor contain quotes or line breaks",
+				value)
+		}
+	}
+
 	return nil
 }
 
 func (p *initSubcommand) Scaffold(fs machinery.Filesystem) error {
-	scaffolder := scaffolds.NewInitScaffolder(p.config)
+	// The leader election ID is recorded by the go plugin before scaffolding, see SetLeaderElectionID.
+	cfg := pluginConfig{}
+	if err := p.config.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return err
+	}
+	scaffolder := scaffolds.NewInitScaffolder(p.config, scaffolds.InitOptions{
+		ChartName:             p.chartName,
+		ChartDir:              p.chartDir,
+		ChartVersion:          p.chartVersion,
+		AppVersion:            p.appVersion,
+		Dependencies:          p.chartDependencies,
+		Maintainers:           p.chartMaintainers,
+		Home:                  p.home,
+		Sources:               p.sources,
+		Keywords:              p.keywords,
+		MetricsAuth:           p.metricsAuth,
+		Image:                 p.image,
+		HealthProbePathPrefix: p.healthProbePathPrefix,
+		CRDsMode:              p.crdsMode,
+		LeaderElectionID:      cfg.LeaderElectionID,
+		PrometheusRules:       p.prometheusRules,
+	})
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }
@@ -17,7 +17,10 @@ limitations under the License.
 package v3
 
 import (
+	"errors"
+
 	"github.com/labring/kubebuilder4helm/plugins"
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	cfgv3 "sigs.k8s.io/kubebuilder/v3/pkg/config/v3"
 	"sigs.k8s.io/kubebuilder/v3/pkg/model/stage"
@@ -32,19 +35,115 @@ const pluginName = "helm.common." + plugins.DefaultNameQualifier
 var (
 	pluginVersion            = plugin.Version{Number: 3, Stage: stage.Stable}
 	supportedProjectVersions = []config.Version{cfgv3.Version}
+	pluginKey                = plugin.KeyFor(Plugin{})
 )
 
 var (
 	_ plugin.Init          = Plugin{}
 	_ plugin.CreateAPI     = Plugin{}
 	_ plugin.CreateWebhook = Plugin{}
+	_ plugin.Edit          = Plugin{}
 )
 
+// pluginConfig is the configuration of the plugin stored in the PROJECT file
+type pluginConfig struct {
+	// ChartName is the name of the chart, set when init didn't default it to the project name
+	ChartName string `json:"chartName,omitempty"`
+	// ChartDir is the directory of the chart relative to the project root, set when init didn't use the default
+	ChartDir string `json:"chartDir,omitempty"`
+	// CertProvider is the provisioner of the webhook serving certificate picked by the first create webhook
+	CertProvider string `json:"certProvider,omitempty"`
+	// SkipCertManagerCheck is set once a create webhook skipped the hook waiting for cert-manager
+	SkipCertManagerCheck bool `json:"skipCertManagerCheck,omitempty"`
+	// HealthProbePathPrefix prefixes the paths of the manager probes, set when init used one
+	HealthProbePathPrefix string `json:"healthProbePathPrefix,omitempty"`
+	// CRDsMode is how the chart installs the CRDs, set when init didn't use the templates default
+	CRDsMode string `json:"crdsMode,omitempty"`
+	// LeaderElectionID is the leader election Lease name built into the manager, set when init overrode it
+	LeaderElectionID string `json:"leaderElectionID,omitempty"`
+}
+
+// initOptions returns the options of the chart stored in the PROJECT file, see scaffolds.InitOptions
+func (cfg pluginConfig) initOptions(chartDir string) scaffolds.InitOptions {
+	return scaffolds.InitOptions{
+		ChartDir:              chartDir,
+		HealthProbePathPrefix: cfg.HealthProbePathPrefix,
+		CRDsMode:              cfg.CRDsMode,
+		LeaderElectionID:      cfg.LeaderElectionID,
+	}
+}
+
+// webhookOptions returns the options of the webhook templates stored in the PROJECT file
+func (cfg pluginConfig) webhookOptions() scaffolds.WebhookOptions {
+	return scaffolds.WebhookOptions{
+		CertProvider:         cfg.CertProvider,
+		SkipCertManagerCheck: cfg.SkipCertManagerCheck,
+	}
+}
+
+// ChartName returns the name of the chart of the project, the project name unless init overrode it
+func ChartName(c config.Config) (string, error) {
+	cfg := pluginConfig{}
+	if err := c.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return "", err
+	}
+	if cfg.ChartName == "" {
+		return c.GetProjectName(), nil
+	}
+	return cfg.ChartName, nil
+}
+
+// ChartDir returns the directory of the chart of the project, relative to the project root
+func ChartDir(c config.Config) (string, error) {
+	cfg := pluginConfig{}
+	if err := c.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return "", err
+	}
+	if cfg.ChartDir == "" {
+		if cfg.ChartName == "" {
+			return scaffolds.DefaultChartDir(c.GetProjectName()), nil
+		}
+		return scaffolds.DefaultChartDir(cfg.ChartName), nil
+	}
+	return cfg.ChartDir, nil
+}
+
+// CRDsDir returns the directory, relative to the chart root, the CRDs of the project are generated into
+func CRDsDir(c config.Config) (string, error) {
+	cfg := pluginConfig{}
+	if err := c.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return "", err
+	}
+	return scaffolds.CRDsDir(cfg.CRDsMode), nil
+}
+
+// HealthProbePathPrefix returns the prefix of the /healthz and /readyz paths the manager serves its probes on,
+// empty when they are served at the root
+func HealthProbePathPrefix(c config.Config) (string, error) {
+	cfg := pluginConfig{}
+	if err := c.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return "", err
+	}
+	return cfg.HealthProbePathPrefix, nil
+}
+
+// SetLeaderElectionID records the leader election Lease name built into the manager, for the chart to pass
+// the same one and only grant access to it
+func SetLeaderElectionID(c config.Config, leaderElectionID string) error {
+	cfg := pluginConfig{}
+	if err := c.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return err
+	}
+	cfg.LeaderElectionID = leaderElectionID
+	return c.EncodePluginConfig(pluginKey, cfg)
+}
+
 // Plugin implements the plugin.Full interface
 type Plugin struct {
 	initSubcommand
 	createAPISubcommand
 	createWebhookSubcommand
+	editSubcommand
 }
 
 // Name returns the name of the plugin
@@ -67,6 +166,9 @@ func (p Plugin) GetCreateWebhookSubcommand() plugin.CreateWebhookSubcommand {
 	return &p.createWebhookSubcommand
 }
 
+// GetEditSubcommand will return the subcommand which is responsible for regenerating the chart
+func (p Plugin) GetEditSubcommand() plugin.EditSubcommand { return &p.editSubcommand }
+
 func (p Plugin) DeprecationWarning() string {
 	return ""
 }
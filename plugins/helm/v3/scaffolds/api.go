@@ -19,6 +19,7 @@ package scaffolds
 import (
 	"fmt"
 
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart/templates"
 	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/samples"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
@@ -40,14 +41,18 @@ type apiScaffolder struct {
 
 	// force indicates whether to scaffold files even if they exist.
 	force bool
+
+	// chartDir is the directory of the chart, relative to the project root
+	chartDir string
 }
 
 // NewAPIScaffolder returns a new Scaffolder for API/controller creation operations
-func NewAPIScaffolder(config config.Config, res resource.Resource, force bool) plugins.Scaffolder {
+func NewAPIScaffolder(config config.Config, res resource.Resource, force bool, chartDir string) plugins.Scaffolder {
 	return &apiScaffolder{
 		config:   config,
 		resource: res,
 		force:    force,
+		chartDir: chartDir,
 	}
 }
 
@@ -68,12 +73,16 @@ func (s *apiScaffolder) Scaffold() error {
 
 	// Keep track of these values before the update
 	if s.resource.HasAPI() {
-		if err := scaffold.Execute(
+		if err := pluginsdk.Execute(scaffold, injectChartDir(s.chartDir,
 			&samples.CRDSample{Force: s.force},
-			&templates.RbacCR{Force: s.force},
+			// Created first for projects scaffolded before the manager ClusterRole existed.
+			&templates.ManagerRole{},
+			&templates.ManagerRoleUpdater{},
+			&templates.AggregatedRoles{},
+			&templates.AggregatedRolesUpdater{},
 			//&rbac.CRDEditorRole{},
 			//&rbac.CRDViewerRole{},
-		); err != nil {
+		)...); err != nil {
 			return fmt.Errorf("error scaffolding kustomize API manifests: %v", err)
 		}
 
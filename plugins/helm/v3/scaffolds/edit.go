@@ -0,0 +1,132 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package scaffolds
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/spf13/afero"
+
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
+	templates2 "github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart/templates"
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+	"sigs.k8s.io/kubebuilder/v3/pkg/plugins"
+)
+
+var _ plugins.Scaffolder = &editScaffolder{}
+
+// editScaffolder regenerates the templates of the chart from the PROJECT file, the Chart.yaml, values.yaml and
+// values.schema.json users tune are never written
+type editScaffolder struct {
+	config config.Config
+
+	// fs is the filesystem that will be used by the scaffolder
+	fs machinery.Filesystem
+
+	// force indicates whether to overwrite the templates users may edit, e.g. the manager ClusterRole
+	force bool
+
+	// opts are the options of the chart stored in the PROJECT file, see InitOptions
+	opts        InitOptions
+	webhookOpts WebhookOptions
+}
+
+// NewEditScaffolder returns a new Scaffolder regenerating the templates of the chart
+func NewEditScaffolder(config config.Config, force bool, opts InitOptions,
+	webhookOpts WebhookOptions) plugins.Scaffolder {
+	return &editScaffolder{
+		config:      config,
+		force:       force,
+		opts:        opts,
+		webhookOpts: webhookOpts,
+	}
+}
+
+// InjectFS implements cmdutil.Scaffolder
+func (s *editScaffolder) InjectFS(fs machinery.Filesystem) {
+	s.fs = fs
+}
+
+// Scaffold implements cmdutil.Scaffolder
+func (s *editScaffolder) Scaffold() error {
+	fmt.Println("Regenerating the helm chart templates...")
+
+	resources, err := s.config.GetResources()
+	if err != nil {
+		return err
+	}
+	webhooks := false
+	for _, res := range resources {
+		if res.Webhooks != nil && !res.Webhooks.IsEmpty() {
+			webhooks = true
+		}
+	}
+
+	// The PrometheusRule is opt-in at init and not recorded in the PROJECT file, keep it when it was scaffolded
+	prometheusRules, err := afero.Exists(s.fs.FS, filepath.Join(s.opts.ChartDir, "templates", "prometheusrule.yaml"))
+	if err != nil {
+		return err
+	}
+
+	scaffold := machinery.NewScaffold(s.fs,
+		machinery.WithConfig(s.config),
+	)
+
+	generateCerts := s.webhookOpts.CertProvider == HelmCertProvider
+	builders := []machinery.Builder{
+		&templates2.Helpers{Force: true, WebhookEnabled: webhooks, GenerateCerts: webhooks && generateCerts,
+			LeaderElectionID: s.opts.LeaderElectionID},
+		&templates2.Notes{Force: true, WebhookEnabled: webhooks, GenerateCerts: webhooks && generateCerts},
+		&templates2.ManagerRole{Force: s.force},
+		&templates2.AggregatedRoles{Force: s.force},
+	}
+	builders = append(builders, managerTemplates(s.opts.HealthProbePathPrefix, s.opts.CRDsMode, prometheusRules)...)
+	if webhooks {
+		webhookBuilders, err := webhookTemplates(s.config, s.force, s.webhookOpts,
+			s.opts.HealthProbePathPrefix)
+		if err != nil {
+			return err
+		}
+		builders = append(builders, webhookBuilders...)
+	}
+	if err := pluginsdk.Execute(scaffold, injectChartDir(s.opts.ChartDir, builders...)...); err != nil {
+		return fmt.Errorf("error regenerating helm chart templates: %v", err)
+	}
+
+	for _, res := range resources {
+		res := res
+		scaffold := machinery.NewScaffold(s.fs,
+			machinery.WithConfig(s.config),
+			machinery.WithResource(&res),
+		)
+
+		var builders []machinery.Builder
+		if res.HasAPI() {
+			builders = append(builders, &templates2.ManagerRoleUpdater{}, &templates2.AggregatedRolesUpdater{})
+		}
+		if res.HasConversionWebhook() {
+			builders = append(builders, &templates2.CRDConversion{Force: s.force, GenerateCerts: generateCerts})
+		}
+		if err := pluginsdk.Execute(scaffold, injectChartDir(s.opts.ChartDir, builders...)...); err != nil {
+			return fmt.Errorf("error regenerating helm chart templates of %s: %v", res.GVK.Kind, err)
+		}
+	}
+
+	return nil
+}
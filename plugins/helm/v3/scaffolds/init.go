@@ -18,7 +18,10 @@ package scaffolds
 
 import (
 	"fmt"
+	"path"
+	"strings"
 
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	templates2 "github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart/templates"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
@@ -28,20 +31,179 @@ import (
 
 const (
 	imageName = "controller:latest"
+
+	// CRDsModeTemplates renders the CRDs generated by controller-gen as release resources upgraded with it
+	CRDsModeTemplates = "templates"
+	// CRDsModeCRDsDir generates the CRDs in the crds directory of the chart, helm installs them before the
+	// other resources of the release but never upgrades nor deletes them
+	CRDsModeCRDsDir = "crds-dir"
+
+	// MetricsAuthRBACProxy serves the metrics through a kube-rbac-proxy sidecar authorizing the scrapes
+	MetricsAuthRBACProxy = "rbac-proxy"
+	// MetricsAuthNone serves the metrics of the manager over plain HTTP
+	MetricsAuthNone = "none"
 )
 
+// ChartDependency is a chart the scaffolded chart depends on
+type ChartDependency = chart.Dependency
+
+// ParseChartDependency parses a chart dependency from [<repository>/]<name>@<version>,
+// e.g. https://charts.bitnami.com/bitnami/redis@17.0.0. Without a repository the chart
+// is expected in the charts directory of the scaffolded chart.
+func ParseChartDependency(value string) (ChartDependency, error) {
+	i := strings.LastIndex(value, "@")
+	if i == -1 {
+		return ChartDependency{}, fmt.Errorf("expected [<repository>/]<name>@<version>")
+	}
+	dependency := ChartDependency{Name: value[:i], Version: value[i+1:]}
+	if j := strings.LastIndex(dependency.Name, "/"); j != -1 {
+		dependency.Repository = dependency.Name[:j]
+		dependency.Name = dependency.Name[j+1:]
+	}
+	if dependency.Name == "" || dependency.Version == "" {
+		return ChartDependency{}, fmt.Errorf("expected [<repository>/]<name>@<version>")
+	}
+	return dependency, nil
+}
+
+// ChartMaintainer is a maintainer listed in the metadata of the scaffolded chart
+type ChartMaintainer = chart.Maintainer
+
+// ParseChartMaintainer parses a chart maintainer from <name>[:<email>], e.g. Jane Doe:jane@example.com
+func ParseChartMaintainer(value string) (ChartMaintainer, error) {
+	maintainer := ChartMaintainer{Name: strings.TrimSpace(value)}
+	if i := strings.LastIndex(value, ":"); i != -1 {
+		maintainer.Name = strings.TrimSpace(value[:i])
+		maintainer.Email = strings.TrimSpace(value[i+1:])
+		if !strings.Contains(maintainer.Email, "@") {
+			return ChartMaintainer{}, fmt.Errorf("expected <name>[:<email>], %q is not an email", maintainer.Email)
+		}
+	}
+	if maintainer.Name == "" {
+		return ChartMaintainer{}, fmt.Errorf("expected <name>[:<email>]")
+	}
+	if strings.ContainsAny(value, "\"\n") {
+		return ChartMaintainer{}, fmt.Errorf("expected <name>[:<email>] without quotes nor line breaks")
+	}
+	return maintainer, nil
+}
+
+// SplitImage splits an image reference into its repository and tag, the tag is empty when none is set,
+// e.g. localhost:5000/operator:v0.1.0 into localhost:5000/operator and v0.1.0.
+func SplitImage(value string) (repository, tag string) {
+	if i := strings.LastIndex(value, ":"); i > strings.LastIndex(value, "/") {
+		return value[:i], value[i+1:]
+	}
+	return value, ""
+}
+
+// CRDsDir returns the directory, relative to the chart root, where `make manifests` writes the CRDs
+// in the given mode, see CRDsModeTemplates and CRDsModeCRDsDir
+func CRDsDir(crdsMode string) string {
+	if crdsMode == CRDsModeCRDsDir {
+		return "crds"
+	}
+	return path.Join("files", "crds")
+}
+
+// DefaultChartDir returns the directory, relative to the project root, the chart is scaffolded in
+// when none is provided
+func DefaultChartDir(chartName string) string {
+	return path.Join("config", chartName)
+}
+
+// injectChartDir sets the chart directory of the builders scaffolding a file of the chart
+func injectChartDir(chartDir string, builders ...machinery.Builder) []machinery.Builder {
+	for _, builder := range builders {
+		if builderWithChartDir, hasChartDir := builder.(chart.HasChartDir); hasChartDir {
+			builderWithChartDir.InjectChartDir(chartDir)
+		}
+	}
+	return builders
+}
+
+// injectChartName sets the chart name of the builders scaffolding a file of the chart
+func injectChartName(chartName string, builders ...machinery.Builder) []machinery.Builder {
+	for _, builder := range builders {
+		if builderWithChartName, hasChartName := builder.(chart.HasChartName); hasChartName {
+			builderWithChartName.InjectChartName(chartName)
+		}
+	}
+	return builders
+}
+
+// managerTemplates returns the builders of the chart templates deploying the manager, always overwritten
+// as they only depend on the options of init stored in the PROJECT file
+func managerTemplates(healthProbePathPrefix, crdsMode string, prometheusRules bool) []machinery.Builder {
+	builders := []machinery.Builder{
+		&templates2.MetricsService{Force: true},
+		&templates2.Monitor{Force: true},
+		&templates2.PodMonitor{Force: true},
+		&templates2.Rbac{Force: true},
+		&templates2.Deployment{Force: true, HealthProbePathPrefix: healthProbePathPrefix},
+		&templates2.ManagerConfig{Force: true},
+		&templates2.PriorityClass{Force: true},
+		&templates2.Namespace{Force: true},
+		&templates2.MigrationJob{Force: true},
+		&templates2.HPA{Force: true},
+		&templates2.VPA{Force: true},
+		&templates2.PodDisruptionBudget{Force: true},
+		&templates2.NetworkPolicy{Force: true},
+		&templates2.ManagerTest{Force: true},
+	}
+	if crdsMode != CRDsModeCRDsDir {
+		builders = append(builders, &templates2.CRDs{Force: true, CRDsDir: CRDsDir(crdsMode)})
+	}
+	if prometheusRules {
+		builders = append(builders, &templates2.PrometheusRule{Force: true})
+	}
+	return builders
+}
+
 var _ plugins.Scaffolder = &initScaffolder{}
 
+// InitOptions are the options the chart is scaffolded with. Edit and create webhook only regenerate the
+// templates from the ones stored in the PROJECT file: ChartDir, HealthProbePathPrefix, CRDsMode and
+// LeaderElectionID.
+type InitOptions struct {
+	// ChartName is the name of the chart, the project name when empty
+	ChartName string
+	// ChartDir is the directory of the chart, relative to the project root
+	ChartDir     string
+	ChartVersion string
+	AppVersion   string
+	Dependencies []ChartDependency
+	Maintainers  []ChartMaintainer
+	Home         string
+	Sources      []string
+	Keywords     []string
+	// MetricsAuth is either MetricsAuthRBACProxy or MetricsAuthNone
+	MetricsAuth string
+	// Image is the manager image stamped into the values, the default one derived from the repository when empty
+	Image string
+	// HealthProbePathPrefix prefixes the paths of the manager probes
+	HealthProbePathPrefix string
+	// CRDsMode is either CRDsModeTemplates or CRDsModeCRDsDir
+	CRDsMode string
+	// LeaderElectionID is the leader election Lease name built into the manager, the default one when empty
+	LeaderElectionID string
+	// PrometheusRules indicates whether to scaffold the PrometheusRule alerting on the manager
+	PrometheusRules bool
+}
+
 type initScaffolder struct {
 	config config.Config
+
+	opts InitOptions
 	// fs is the filesystem that will be used by the scaffolder
 	fs machinery.Filesystem
 }
 
 // NewInitScaffolder returns a new Scaffolder for project initialization operations
-func NewInitScaffolder(config config.Config) plugins.Scaffolder {
+func NewInitScaffolder(config config.Config, opts InitOptions) plugins.Scaffolder {
 	return &initScaffolder{
 		config: config,
+		opts:   opts,
 	}
 }
 
@@ -54,6 +216,9 @@ func (s *initScaffolder) InjectFS(fs machinery.Filesystem) {
 func (s *initScaffolder) Scaffold() error {
 	fmt.Println("Writing helm manifests for you to edit...")
 
+	imageRepository, imageTag := SplitImage(s.opts.Image)
+	templatedCRDs := s.opts.CRDsMode != CRDsModeCRDsDir
+
 	// Initialize the machinery.Scaffold that will write the files to disk
 	scaffold := machinery.NewScaffold(s.fs,
 		machinery.WithConfig(s.config),
@@ -76,15 +241,27 @@ func (s *initScaffolder) Scaffold() error {
 		//&kdefault2.ManagerConfigPatch{},
 		//&prometheus2.Kustomization{},
 		//&prometheus2.Monitor{},
-		&chart.Chart{},
+		&chart.Chart{Version: s.opts.ChartVersion, AppVersion: s.opts.AppVersion, Dependencies: s.opts.Dependencies,
+			Maintainers: s.opts.Maintainers, Home: s.opts.Home, Sources: s.opts.Sources, Keywords: s.opts.Keywords},
 		&chart.HelmIgnore{},
-		&chart.Values{},
-		&templates2.Helpers{},
-		&templates2.MonitorService{Force: true},
-		&templates2.Monitor{Force: true},
-		&templates2.Rbac{Force: true},
-		&templates2.Deployment{Force: true},
+		&chart.Values{Dependencies: s.opts.Dependencies, MetricsAuth: s.opts.MetricsAuth,
+			ImageRepository: imageRepository, ImageTag: imageTag, TemplatedCRDs: templatedCRDs,
+			PrometheusRules: s.opts.PrometheusRules},
+		&chart.ValuesSchema{Dependencies: s.opts.Dependencies, TemplatedCRDs: templatedCRDs,
+			PrometheusRules: s.opts.PrometheusRules},
+		&templates2.Helpers{LeaderElectionID: s.opts.LeaderElectionID},
+		&templates2.ManagerRole{},
+		&templates2.AggregatedRoles{},
+		&templates2.Notes{Force: true},
+	}
+	templates = append(templates, managerTemplates(s.opts.HealthProbePathPrefix, s.opts.CRDsMode, s.opts.PrometheusRules)...)
+	if len(s.opts.Dependencies) != 0 {
+		templates = append(templates, &chart.ChartsGitIgnore{})
 	}
 
-	return scaffold.Execute(templates...)
+	chartName := s.opts.ChartName
+	if chartName == "" {
+		chartName = s.config.GetProjectName()
+	}
+	return pluginsdk.Execute(scaffold, injectChartName(chartName, injectChartDir(s.opts.ChartDir, templates...)...)...)
 }
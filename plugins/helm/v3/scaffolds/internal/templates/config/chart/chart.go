@@ -24,18 +24,74 @@ import (
 
 var _ machinery.Template = &Chart{}
 
-// Chart scaffolds a file that defines the kustomization scheme for the webhook folder
+const (
+	// DefaultChartVersion is the chart version used when none is provided
+	DefaultChartVersion = "0.0.0"
+	// DefaultAppVersion is the chart appVersion used when none is provided
+	DefaultAppVersion = "0.0.0"
+)
+
+// Dependency is a chart the scaffolded chart depends on
+type Dependency struct {
+	Name    string
+	Version string
+	// Repository is the URL of the chart repository, the chart is expected in the charts
+	// directory when empty
+	Repository string
+}
+
+// Maintainer is a maintainer of the scaffolded chart
+type Maintainer struct {
+	Name string
+	// Email is omitted from the chart when empty
+	Email string
+}
+
+// Chart scaffolds the Chart.yaml file that defines the helm chart metadata
 type Chart struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
+	ChartNameMixin
+	ChartDirMixin
 	machinery.RepositoryMixin
+
+	// Version is the chart version, it must be a valid semantic version
+	Version string
+	// AppVersion is the version of the manager image the chart deploys
+	AppVersion string
+	// Dependencies are the charts installed together with this one
+	Dependencies []Dependency
+	// Maintainers are listed in the chart metadata, e.g. by Artifact Hub
+	Maintainers []Maintainer
+	// Home is the URL of the project home page, the repository of the project when empty
+	Home string
+	// Sources are the URLs of the project source code, the repository of the project when empty
+	Sources []string
+	// Keywords are the search terms of the chart, its name when empty
+	Keywords []string
+
 	Force bool
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *Chart) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "Chart.yaml")
+		f.Path = filepath.Join(f.ChartDir, "Chart.yaml")
+	}
+
+	if f.Version == "" {
+		f.Version = DefaultChartVersion
+	}
+	if f.AppVersion == "" {
+		f.AppVersion = DefaultAppVersion
+	}
+	if f.Home == "" {
+		f.Home = "https://" + f.Repo
+	}
+	if len(f.Sources) == 0 {
+		f.Sources = []string{"https://" + f.Repo}
+	}
+	if len(f.Keywords) == 0 {
+		f.Keywords = []string{f.ChartName}
 	}
 
 	f.TemplateBody = chartTemplate
@@ -51,15 +107,39 @@ func (f *Chart) SetTemplateDefaults() error {
 }
 
 const chartTemplate = `apiVersion: v2
-name: {{ .ProjectName }}
+name: {{ .ChartName }}
 description: A Helm chart for Kubernetes auto generated by kubebuilder4helm
 kubeVersion: "^1.22.0-0"
 sources:
-  - https://{{ .Repo }}
-home: https://{{ .Repo }}
+{{- range .Sources }}
+  - {{ . }}
+{{- end }}
+home: {{ .Home }}
 keywords:
-  - {{ .ProjectName }}
+{{- range .Keywords }}
+  - {{ printf "%q" . }}
+{{- end }}
+{{- with .Maintainers }}
+maintainers:
+{{- range . }}
+  - name: {{ printf "%q" .Name }}
+    {{- if .Email }}
+    email: {{ printf "%q" .Email }}
+    {{- end }}
+{{- end }}
+{{- end }}
 type: application
-version: 0.0.0
-appVersion: "0.0.0"
+version: {{ .Version }}
+appVersion: "{{ .AppVersion }}"
+{{- with .Dependencies }}
+dependencies:
+{{- range . }}
+  - name: {{ .Name }}
+    version: "{{ .Version }}"
+    {{- if .Repository }}
+    repository: "{{ .Repository }}"
+    {{- end }}
+    condition: {{ .Name }}.enabled
+{{- end }}
+{{- end }}
 `
@@ -0,0 +1,49 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chart
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &ChartsGitIgnore{}
+
+// ChartsGitIgnore scaffolds the charts directory holding the dependencies of the chart, the
+// archives fetched by helm are kept out of git while unpacked local charts are committed.
+type ChartsGitIgnore struct {
+	machinery.TemplateMixin
+	ChartDirMixin
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *ChartsGitIgnore) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "charts", ".gitignore")
+	}
+
+	f.TemplateBody = chartsGitIgnoreTemplate
+
+	f.IfExistsAction = machinery.SkipFile
+
+	return nil
+}
+
+const chartsGitIgnoreTemplate = `# Archives of the chart dependencies, fetched by helm dependency build
+*.tgz
+`
@@ -27,7 +27,7 @@ var _ machinery.Template = &HelmIgnore{}
 // HelmIgnore scaffolds a file that defines the kustomization scheme for the webhook folder
 type HelmIgnore struct {
 	machinery.TemplateMixin
-	machinery.ProjectNameMixin
+	ChartDirMixin
 
 	Force bool
 }
@@ -35,7 +35,7 @@ type HelmIgnore struct {
 // SetTemplateDefaults implements file.Template
 func (f *HelmIgnore) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, ".helmignore")
+		f.Path = filepath.Join(f.ChartDir, ".helmignore")
 	}
 
 	f.TemplateBody = helmIgnoreTemplate
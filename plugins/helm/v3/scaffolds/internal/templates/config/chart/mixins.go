@@ -0,0 +1,56 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chart
+
+// HasChartDir allows the chart directory to be used on a template
+type HasChartDir interface {
+	// InjectChartDir sets the template chart directory
+	InjectChartDir(string)
+}
+
+// ChartDirMixin provides templates with an injectable chart directory field
+type ChartDirMixin struct {
+	// ChartDir is the directory of the chart, relative to the project root
+	ChartDir string
+}
+
+// InjectChartDir implements HasChartDir
+func (m *ChartDirMixin) InjectChartDir(dir string) {
+	if m.ChartDir == "" {
+		m.ChartDir = dir
+	}
+}
+
+// HasChartName allows the chart name to be used on a template
+type HasChartName interface {
+	// InjectChartName sets the template chart name
+	InjectChartName(string)
+}
+
+// ChartNameMixin provides templates with an injectable chart name field, it defaults to the project name
+// but may differ from it, e.g. to prefix the chart with the team owning it
+type ChartNameMixin struct {
+	// ChartName is the name of the chart in Chart.yaml
+	ChartName string
+}
+
+// InjectChartName implements HasChartName
+func (m *ChartNameMixin) InjectChartName(name string) {
+	if m.ChartName == "" {
+		m.ChartName = name
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &CRDConversion{}
+
+// CRDConversion scaffolds a hook that patches the resource CRD to be converted by the webhook.
+// The CRDs are rendered verbatim from the controller-gen output, so the conversion block that
+// points at the release webhook service has to be set once the release is installed.
+type CRDConversion struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	machinery.ResourceMixin
+	Force bool
+	// GenerateCerts indicates the webhook serving certificate is generated by helm instead of cert-manager
+	GenerateCerts bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *CRDConversion) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "crd_conversion_%[group]_%[kind].yaml")
+	}
+	f.Path = f.Resource.Replacer().Replace(f.Path)
+
+	f.TemplateBody = crdConversionTemplate
+	f.SetDelim("[[", "]]")
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const crdConversionTemplate = `{{- if include "[[ .ProjectName ]].webhookEnabled" . -}}
+{{- $name := printf "%s-[[ lower .Resource.Kind ]]-conversion" (include "[[ .ProjectName ]].fullname" .) -}}
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-install,post-upgrade
+    "helm.sh/hook-weight": "-10"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-install,post-upgrade
+    "helm.sh/hook-weight": "-10"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+rules:
+- apiGroups:
+  - apiextensions.k8s.io
+  resources:
+  - customresourcedefinitions
+  resourceNames:
+  - [[ .Resource.Plural ]].[[ .Resource.QualifiedGroup ]]
+  verbs:
+  - get
+  - patch
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-install,post-upgrade
+    "helm.sh/hook-weight": "-10"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: {{ $name }}
+subjects:
+- kind: ServiceAccount
+  name: {{ $name }}
+  namespace: {{ .Release.Namespace }}
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-install,post-upgrade
+    "helm.sh/hook-weight": "-5"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+spec:
+  template:
+    metadata:
+      name: {{ $name }}
+      labels:
+        {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 8 }}
+    spec:
+      restartPolicy: Never
+      serviceAccountName: {{ $name }}
+      {{- with .Values.imagePullSecrets }}
+      imagePullSecrets:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      containers:
+        - name: crd-conversion
+          {{- /* The values of charts scaffolded before the conversion image may not have it */}}
+          {{- $image := dig "conversionImage" (dict) .Values.webhook }}
+          image: "{{ $image.repository | default "bitnami/kubectl" }}:{{ $image.tag | default "latest" }}"
+          imagePullPolicy: {{ $image.pullPolicy | default "IfNotPresent" }}
+          args:
+            - patch
+            - customresourcedefinitions.apiextensions.k8s.io
+            - [[ .Resource.Plural ]].[[ .Resource.QualifiedGroup ]]
+            - --type=merge
+            - --patch
+            - |-
+              [[- if not .GenerateCerts ]]
+              {{- if not (or (include "[[ .ProjectName ]].webhookCABundle" .) (include "[[ .ProjectName ]].webhookIngress" .)) }}
+              metadata:
+                annotations:
+                  cert-manager.io/inject-ca-from: {{ .Release.Namespace }}/{{ include "[[ .ProjectName ]].servingCertName" . }}
+              {{- end }}
+              [[- end ]]
+              spec:
+                conversion:
+                  strategy: Webhook
+                  webhook:
+                    conversionReviewVersions:
+                    - v1
+                    clientConfig:
+                      {{- with include "[[ .ProjectName ]].webhookCABundle" . }}
+                      caBundle: {{ . }}
+                      {{- end }}
+                      {{- if include "[[ .ProjectName ]].webhookIngress" . }}
+                      url: https://{{ .Values.webhook.ingress.host }}/convert
+                      {{- else }}
+                      service:
+                        port: {{ .Values.webhook.service.port }}
+                        namespace: {{ .Release.Namespace }}
+                        name: {{ include "[[ .ProjectName ]].fullname" . }}-webhook-service
+                        path: /convert
+                      {{- end }}
+{{- end }}
+`
@@ -0,0 +1,81 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &CRDs{}
+
+// CRDs scaffolds a file that renders the CRDs generated by controller-gen as part of the release
+type CRDs struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	Force bool
+
+	// CRDsDir is the directory, relative to the chart root, where controller-gen writes the CRDs
+	CRDsDir string
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *CRDs) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "crds.yaml")
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = crdsTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const crdsTemplate = `{{- /*
+The CRDs generated by controller-gen under [[ .CRDsDir ]] are rendered as regular
+release resources instead of being placed in the chart crds/ directory:
+  - crds/ is only applied on the first install, helm never upgrades nor deletes
+    those CRDs, so schema changes require applying them by hand.
+  - templated CRDs are upgraded with the release, and crds.keep annotates them with
+    helm.sh/resource-policy: keep so uninstalling the release doesn't delete them
+    together with every custom resource in the cluster.
+Set crds.install to false to manage the CRDs out of band (e.g. make install).
+*/ -}}
+{{- if .Values.crds.install }}
+{{- range $path, $_ := .Files.Glob "[[ .CRDsDir ]]/*.yaml" }}
+{{- $crd := $.Files.Get $path | fromYaml }}
+{{- $annotations := $crd.metadata.annotations | default dict }}
+{{- if $.Values.crds.keep }}
+{{- $annotations = merge (dict "helm.sh/resource-policy" "keep") $annotations }}
+{{- end }}
+{{- $_ := set $crd.metadata "annotations" (merge $annotations (include "[[ .ProjectName ]].waveAnnotations" (list $ "crds") | fromYaml)) }}
+{{- with $.Values.commonLabels }}
+{{- $_ := set $crd.metadata "labels" (merge ($crd.metadata.labels | default dict) .) }}
+{{- end }}
+---
+{{ toYaml $crd }}
+{{- end }}
+{{- end }}
+`
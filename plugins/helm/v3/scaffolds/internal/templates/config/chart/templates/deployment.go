@@ -19,6 +19,7 @@ package templates
 import (
 	"path/filepath"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
@@ -28,14 +29,17 @@ var _ machinery.Template = &Deployment{}
 type Deployment struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 
 	Force bool
+	// HealthProbePathPrefix prefixes the /healthz and /readyz paths the manager serves its probes on
+	HealthProbePathPrefix string
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *Deployment) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "deployment.yaml")
+		f.Path = filepath.Join(f.ChartDir, "templates", "deployment.yaml")
 	}
 
 	f.TemplateBody = deploymentTemplate
@@ -55,36 +59,83 @@ metadata:
   name: {{ include "[[ .ProjectName ]].fullname" . }}
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 spec:
+  {{- if not .Values.autoscaling.enabled }}
   replicas: {{ .Values.replicaCount }}
+  {{- end }}
+  strategy:
+    type: {{ .Values.updateStrategy.type }}
+    {{- if eq .Values.updateStrategy.type "RollingUpdate" }}
+    {{- with .Values.updateStrategy.rollingUpdate }}
+    rollingUpdate:
+      {{- toYaml . | nindent 6 }}
+    {{- end }}
+    {{- end }}
+  revisionHistoryLimit: {{ .Values.revisionHistoryLimit }}
   selector:
     matchLabels:
       {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 6 }}
   template:
     metadata:
-      {{- with .Values.podAnnotations }}
+      {{- if or .Values.managerConfig.enabled .Values.podAnnotations }}
       annotations:
+        {{- if .Values.managerConfig.enabled }}
+        checksum/manager-config: {{ include "[[ .ProjectName ]].managerFlags" . | sha256sum }}
+        {{- end }}
+        {{- with .Values.podAnnotations }}
         {{- toYaml . | nindent 8 }}
+        {{- end }}
       {{- end }}
       labels:
         {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 8 }}
+        {{- with .Values.commonLabels }}
+        {{- toYaml . | nindent 8 }}
+        {{- end }}
     spec:
-      serviceAccountName: {{ include "[[ .ProjectName ]].fullname" . }}
+      {{- with .Values.imagePullSecrets }}
+      imagePullSecrets:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      serviceAccountName: {{ include "[[ .ProjectName ]].serviceAccountName" . }}
+      {{- with include "[[ .ProjectName ]].priorityClassName" . }}
+      priorityClassName: {{ . }}
+      {{- end }}
+      terminationGracePeriodSeconds: {{ .Values.terminationGracePeriodSeconds }}
+      {{- if .Values.hostNetwork }}
+      hostNetwork: true
+      {{- end }}
+      dnsPolicy: {{ .Values.dnsPolicy }}
+      {{- with .Values.podSecurityContext }}
+      securityContext:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
       containers:
         - name: {{ .Chart.Name }}
           command:
+          {{- with .Values.main.command }}
+          {{- toYaml . | nindent 10 }}
+          {{- else }}
           - /manager
+          {{- end }}
           args:
-            - --health-probe-bind-address=:8081
-            - --metrics-bind-address=127.0.0.1:8080
-            - --leader-elect
-            - --zap-devel={{ .Values.logger.zap }}
-            - --zap-log-level={{ .Values.logger.level }}
-            - --default-burst={{ .Values.rateLimiter.defaultBurst }}
-            - --default-concurrent={{ .Values.rateLimiter.defaultConcurrent }}
-            - --default-qps={{ .Values.rateLimiter.defaultQPS }}
-            - --max-retry-delay={{ .Values.rateLimiter.maxRetryDelay }}
-            - --min-retry-delay={{ .Values.rateLimiter.minRetryDelay }}
+            {{- with .Values.main.args }}
+            {{- toYaml . | nindent 12 }}
+            {{- else }}
+            {{- if .Values.managerConfig.enabled }}
+            - --config=/controller_manager_config.yaml
+            {{- else }}
+            {{- range $name, $value := include "[[ .ProjectName ]].managerFlags" . | fromYaml }}
+            - --{{ $name }}={{ $value }}
+            {{- end }}
+            {{- end }}
+            {{- with .Values.main.extraArgs }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
+            {{- end }}
           securityContext:
             {{- toYaml .Values.main.securityContext | nindent 12 }}
           image: "{{ .Values.main.image.repository }}:{{ .Values.main.image.tag | default .Chart.AppVersion }}"
@@ -93,22 +144,58 @@ spec:
           - containerPort: 8081
             name: health
             protocol: TCP
+          {{- if not (include "[[ .ProjectName ]].metricsRBACProxy" .) }}
+          - containerPort: 8080
+            name: metrics
+            protocol: TCP
+          {{- end }}
+          {{- if include "[[ .ProjectName ]].managerWebhooks" . }}
+          - containerPort: {{ .Values.webhook.port }}
+            name: webhook-server
+            protocol: TCP
+          {{- end }}
           livenessProbe:
             httpGet:
-              path: /healthz
+              path: [[ .HealthProbePathPrefix ]]/healthz
               port: health
+            {{- with .Values.livenessProbe }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
           readinessProbe:
             httpGet:
-              path: /readyz
+              path: [[ .HealthProbePathPrefix ]]/readyz
               port: health
+            {{- with .Values.readinessProbe }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
           resources:
             {{- toYaml .Values.main.resources | nindent 12 }}
-          {{- if include "[[ .ProjectName ]].webhookEnabled" . -}}
+          {{- with .Values.extraEnv }}
+          env:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          {{- with .Values.extraEnvFrom }}
+          envFrom:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          {{- if or (include "[[ .ProjectName ]].managerWebhooks" .) .Values.managerConfig.enabled .Values.extraVolumeMounts }}
           volumeMounts:
+            {{- if include "[[ .ProjectName ]].managerWebhooks" . }}
             - mountPath: /tmp/k8s-webhook-server/serving-certs
               name: cert
               readOnly: true
+            {{- end }}
+            {{- if .Values.managerConfig.enabled }}
+            - mountPath: /controller_manager_config.yaml
+              name: manager-config
+              subPath: controller_manager_config.yaml
+              readOnly: true
+            {{- end }}
+            {{- with .Values.extraVolumeMounts }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
           {{- end }}
+        {{- if include "[[ .ProjectName ]].metricsRBACProxy" . }}
         - name: kube-rbac-proxy
           args:
             - --secure-listen-address=0.0.0.0:8443
@@ -125,6 +212,10 @@ spec:
               protocol: TCP
           resources:
             {{- toYaml .Values.proxy.resources | nindent 12 }}
+        {{- end }}
+        {{- with .Values.extraContainers }}
+        {{- toYaml . | nindent 8 }}
+        {{- end }}
       {{- with .Values.nodeSelector }}
       nodeSelector:
         {{- toYaml . | nindent 8 }}
@@ -137,11 +228,31 @@ spec:
       tolerations:
         {{- toYaml . | nindent 8 }}
       {{- end }}
-      {{- if include "[[ .ProjectName ]].webhookEnabled" . -}}
+      {{- with .Values.topologySpreadConstraints }}
+      topologySpreadConstraints:
+        {{- range . }}
+        {{- $constraint := deepCopy . }}
+        {{- if not (hasKey $constraint "labelSelector") }}
+        {{- $_ := set $constraint "labelSelector" (dict "matchLabels" (include "[[ .ProjectName ]].selectorLabels" $ | fromYaml)) }}
+        {{- end }}
+        {{- list $constraint | toYaml | nindent 8 }}
+        {{- end }}
+      {{- end }}
+      {{- if or (include "[[ .ProjectName ]].managerWebhooks" .) .Values.managerConfig.enabled .Values.extraVolumes }}
       volumes:
+        {{- if include "[[ .ProjectName ]].managerWebhooks" . }}
         - name: cert
           secret:
             defaultMode: 420
-            secretName: {{ include "[[ .ProjectName ]].fullname" . }}-webhook-server-cert
+            secretName: {{ include "[[ .ProjectName ]].webhookCertSecretName" . }}
+        {{- end }}
+        {{- if .Values.managerConfig.enabled }}
+        - name: manager-config
+          configMap:
+            name: {{ include "[[ .ProjectName ]].fullname" . }}-manager-config
+        {{- end }}
+        {{- with .Values.extraVolumes }}
+        {{- toYaml . | nindent 8 }}
+        {{- end }}
       {{- end }}
 `
@@ -21,6 +21,7 @@ import (
 	"path/filepath"
 	"text/template"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
@@ -30,15 +31,22 @@ var _ machinery.Template = &Helpers{}
 type Helpers struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 	machinery.RepositoryMixin
+	machinery.DomainMixin
 	Force          bool
 	WebhookEnabled bool
+	// GenerateCerts indicates the webhook serving certificate is generated by helm instead of cert-manager
+	GenerateCerts bool
+	// LeaderElectionID is the leader election Lease name built into the manager, derived from the repository
+	// and the domain when empty
+	LeaderElectionID string
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *Helpers) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "_helpers.tpl")
+		f.Path = filepath.Join(f.ChartDir, "templates", "_helpers.tpl")
 	}
 	f.SetDelim("[[", "]]")
 
@@ -104,6 +112,37 @@ helm.sh/chart: {{ include "[[ .ProjectName ]].chart" . }}
 app.kubernetes.io/version: {{ .Chart.AppVersion | quote }}
 {{- end }}
 app.kubernetes.io/managed-by: {{ .Release.Service }}
+{{- with .Values.commonLabels }}
+{{ toYaml . }}
+{{- end }}
+{{- end }}
+
+{{/*
+Common annotations
+*/}}
+{{- define "[[ .ProjectName ]].annotations" -}}
+{{- with .Values.commonAnnotations }}
+{{- toYaml . }}
+{{- end }}
+{{- end }}
+
+{{/*
+Common annotations with the Argo CD sync-wave of a class of resources when argocd.syncWaves.enabled,
+called with (list . "<class>") where the class is one of the argocd.syncWaves keys. The values of charts
+scaffolded before the sync-waves may not have them
+*/}}
+{{- define "[[ .ProjectName ]].waveAnnotations" -}}
+{{- $root := index . 0 }}
+{{- $annotations := $root.Values.commonAnnotations | default dict | deepCopy }}
+{{- $syncWaves := dig "syncWaves" (dict) ($root.Values.argocd | default dict) }}
+{{- if dig "enabled" false $syncWaves }}
+{{- $defaults := dict "crds" -3 "rbac" -2 "certificates" -1 "manager" 0 "webhooks" 1 }}
+{{- $wave := dig (index . 1) (index $defaults (index . 1)) $syncWaves }}
+{{- $_ := set $annotations "argocd.argoproj.io/sync-wave" ($wave | toString) }}
+{{- end }}
+{{- with $annotations }}
+{{- toYaml . }}
+{{- end }}
 {{- end }}
 
 {{/*
@@ -114,7 +153,238 @@ app.kubernetes.io/name: {{ include "[[ .ProjectName ]].name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
 
-{{- define "[[ .ProjectName ]].webhookEnabled" }}
-{{- "[[ .WebhookEnabled ]]" }}
+{{/*
+Create the name of the service account to use
+*/}}
+{{- define "[[ .ProjectName ]].serviceAccountName" -}}
+{{- if .Values.serviceAccount.create }}
+{{- default (printf "%s-controller-manager" (include "[[ .ProjectName ]].fullname" .)) .Values.serviceAccount.name }}
+{{- else }}
+{{- default "default" .Values.serviceAccount.name }}
+{{- end }}
+{{- end }}
+
+{{/*
+Create the name of the priority class of the manager pod, empty when none is used
+*/}}
+{{- define "[[ .ProjectName ]].priorityClassName" -}}
+{{- if .Values.priorityClassName }}
+{{- .Values.priorityClassName }}
+{{- else if .Values.priorityClass.create }}
+{{- include "[[ .ProjectName ]].fullname" . }}
+{{- end }}
+{{- end }}
+
+{{/*
+Render "true" when the project scaffolds webhooks and webhook.enabled is set, empty otherwise
+*/}}
+{{- define "[[ .ProjectName ]].webhookEnabled" -}}
+{{- if and [[ .WebhookEnabled ]] .Values.webhook.enabled }}true{{- end }}
+{{- end }}
+
+{{/*
+Render "true" when the API server calls the webhooks through the Ingress of webhook.ingress.host instead
+of the webhook Service, empty otherwise
+*/}}
+{{- define "[[ .ProjectName ]].webhookIngress" -}}
+{{- if and (include "[[ .ProjectName ]].webhookEnabled" .) (dig "ingress" "enabled" false .Values.webhook) }}true{{- end }}
+{{- end }}
+
+{{/*
+Render "true" when the webhooks are served by a Deployment of their own running the manager with
+--disable-controllers, empty when the manager pods serve them or there are none
+*/}}
+{{- define "[[ .ProjectName ]].webhookDeployment" -}}
+{{- if and (include "[[ .ProjectName ]].webhookEnabled" .) .Values.webhook.deployment.enabled }}true{{- end }}
+{{- end }}
+
+{{/*
+Render "true" when the manager pods serve the webhooks, empty otherwise
+*/}}
+{{- define "[[ .ProjectName ]].managerWebhooks" -}}
+{{- if and (include "[[ .ProjectName ]].webhookEnabled" .) (not .Values.webhook.deployment.enabled) }}true{{- end }}
+{{- end }}
+
+{{/*
+Selector labels of the pods serving the webhooks, the webhook Deployment ones don't match the manager pods
+*/}}
+{{- define "[[ .ProjectName ]].webhookSelectorLabels" -}}
+{{- if include "[[ .ProjectName ]].webhookDeployment" . -}}
+app.kubernetes.io/name: {{ printf "%s-webhook" (include "[[ .ProjectName ]].name" .) | trunc 63 | trimSuffix "-" }}
+app.kubernetes.io/instance: {{ .Release.Name }}
+{{- else -}}
+{{ include "[[ .ProjectName ]].selectorLabels" . }}
+{{- end }}
+{{- end }}
+
+{{/*
+Egress rules of the NetworkPolicies of the manager and webhook pods, only letting out the DNS queries,
+the API server calls and networkPolicy.egress.extraRules
+*/}}
+{{- define "[[ .ProjectName ]].networkPolicyEgress" -}}
+- ports:
+    - port: 53
+      protocol: UDP
+    - port: 53
+      protocol: TCP
+  to:
+    - namespaceSelector:
+        {{- toYaml .Values.networkPolicy.egress.dns.namespaceSelector | nindent 8 }}
+      podSelector:
+        {{- toYaml .Values.networkPolicy.egress.dns.podSelector | nindent 8 }}
+- ports:
+    {{- range .Values.networkPolicy.egress.apiServer.ports }}
+    - port: {{ . }}
+      protocol: TCP
+    {{- end }}
+  {{- with .Values.networkPolicy.egress.apiServer.cidrs }}
+  to:
+    {{- range . }}
+    - ipBlock:
+        cidr: {{ . }}
+    {{- end }}
+  {{- end }}
+{{- with .Values.networkPolicy.egress.extraRules }}
+{{ toYaml . }}
+{{- end }}
+{{- end }}
+
+{{/*
+Render "true" when the metrics are served through the kube-rbac-proxy sidecar, empty otherwise
+*/}}
+{{- define "[[ .ProjectName ]].metricsRBACProxy" -}}
+{{- if eq .Values.metrics.auth "rbac-proxy" }}true{{- end }}
+{{- end }}
+
+{{/*
+Name of the leader election Lease, defaults to the one built into the manager. The manager is only granted
+access to this Lease
+*/}}
+{{- define "[[ .ProjectName ]].leaderElectionID" -}}
+[[- if .LeaderElectionID ]]
+{{- .Values.leaderElection.id | default "[[ .LeaderElectionID ]]" }}
+[[- else ]]
+{{- .Values.leaderElection.id | default "[[ hashFNV .Repo ]].[[ .Domain ]]" }}
+[[- end ]]
+{{- end }}
+
+{{/*
+Flags of the manager keyed by name, passed as container args or through the --config file, the ones
+set to an empty string in managerConfig.extraFlags are left to the manager defaults
+*/}}
+{{- define "[[ .ProjectName ]].managerFlags" -}}
+{{- $flags := dict "health-probe-bind-address" ":8081" "metrics-bind-address" ":8080" }}
+{{- if include "[[ .ProjectName ]].metricsRBACProxy" . }}
+{{- $_ := set $flags "metrics-bind-address" "127.0.0.1:8080" }}
+{{- end }}
+{{- $_ := set $flags "leader-elect" .Values.leaderElection.enabled }}
+{{- $_ := set $flags "leader-election-id" (include "[[ .ProjectName ]].leaderElectionID" .) }}
+{{- if include "[[ .ProjectName ]].webhookEnabled" . }}
+{{- $_ := set $flags "webhook-port" .Values.webhook.port }}
+{{- end }}
+{{- if not (include "[[ .ProjectName ]].managerWebhooks" .) }}
+{{- $_ := set $flags "disable-webhooks" true }}
+{{- end }}
+{{- $_ := set $flags "zap-devel" .Values.logger.zap }}
+{{- $_ := set $flags "zap-log-level" .Values.logger.level }}
+{{- $_ := set $flags "log-json" .Values.logger.json }}
+{{- $_ := set $flags "default-burst" .Values.rateLimiter.defaultBurst }}
+{{- $_ := set $flags "default-concurrent" .Values.rateLimiter.defaultConcurrent }}
+{{- $_ := set $flags "default-qps" .Values.rateLimiter.defaultQPS }}
+{{- $_ := set $flags "max-retry-delay" .Values.rateLimiter.maxRetryDelay }}
+{{- $_ := set $flags "min-retry-delay" .Values.rateLimiter.minRetryDelay }}
+{{- with .Values.featureGates }}
+{{- $gates := list }}
+{{- range $name, $enabled := . }}
+{{- $gates = append $gates (printf "%s=%t" $name $enabled) }}
+{{- end }}
+{{- $_ := set $flags "feature-gates" (join "," $gates) }}
+{{- end }}
+{{- $flags = mustMergeOverwrite $flags .Values.managerConfig.extraFlags }}
+{{- range $name, $value := $flags }}
+{{- if eq (toString $value) "" }}
+{{- $_ := unset $flags $name }}
+{{- end }}
+{{- end }}
+{{- toYaml $flags }}
+{{- end }}
+[[- if .GenerateCerts ]]
+
+{{/*
+Webhook serving certificate as a dict of PEM encoded ca, cert and key. The existing Secret is
+reused so upgrades don't rotate it, otherwise the CA and the certificate are generated once per
+render and shared by the Secret and the webhook configurations
+*/}}
+{{- define "[[ .ProjectName ]].webhookCerts" -}}
+{{- if not (hasKey .Values "_webhookCerts") }}
+{{- $service := printf "%s-webhook-service" (include "[[ .ProjectName ]].fullname" .) }}
+{{- $secret := lookup "v1" "Secret" .Release.Namespace (printf "%s-webhook-server-cert" (include "[[ .ProjectName ]].fullname" .)) }}
+{{- $certs := dict }}
+{{- if $secret }}
+{{- $certs = dict "ca" (index $secret.data "ca.crt" | b64dec) "cert" (index $secret.data "tls.crt" | b64dec) "key" (index $secret.data "tls.key" | b64dec) }}
+{{- else }}
+{{- $ca := genCA (printf "%s-ca" $service) 3650 }}
+{{- $altNames := list (printf "%s.%s.svc" $service .Release.Namespace) (printf "%s.%s.svc.cluster.local" $service .Release.Namespace) }}
+{{- $cert := genSignedCert $service nil $altNames 3650 $ca }}
+{{- $certs = dict "ca" $ca.Cert "cert" $cert.Cert "key" $cert.Key }}
+{{- end }}
+{{- $_ := set .Values "_webhookCerts" $certs }}
+{{- end }}
+{{- toYaml (get .Values "_webhookCerts") }}
+{{- end }}
+[[- end ]]
+
+{{/*
+Name of the cert-manager Certificate of the webhook serving certificate, the webhook configurations
+and the CRDs converted by the webhook inject its CA from <release namespace>/<name>
+*/}}
+{{- define "[[ .ProjectName ]].servingCertName" -}}
+{{- printf "%s-serving-cert" (include "[[ .ProjectName ]].fullname" .) }}
+{{- end }}
+
+{{/*
+Name of the Secret holding the webhook serving certificate, webhook.existingSecret when the chart
+doesn't own it
+*/}}
+{{- define "[[ .ProjectName ]].webhookCertSecretName" -}}
+{{- .Values.webhook.existingSecret | default (printf "%s-webhook-server-cert" (include "[[ .ProjectName ]].fullname" .)) }}
+{{- end }}
+
+{{/*
+Annotations of the webhook configurations generated by controller-gen, cert-manager injects the CA
+bundle from the serving Certificate when the chart doesn't set it
+*/}}
+{{- define "[[ .ProjectName ]].webhookConfigAnnotations" -}}
+{{- $annotations := include "[[ .ProjectName ]].waveAnnotations" (list . "webhooks") | fromYaml }}
+{{- if not (or (include "[[ .ProjectName ]].webhookCABundle" .) (include "[[ .ProjectName ]].webhookIngress" .)) }}
+{{- $_ := set $annotations "cert-manager.io/inject-ca-from" (printf "%s/%s" .Release.Namespace (include "[[ .ProjectName ]].servingCertName" .)) }}
+{{- end }}
+{{- with $annotations }}
+{{- toYaml . }}
+{{- end }}
+{{- end }}
+
+{{/*
+Base64 encoded CA bundle of the webhook configurations, empty when cert-manager injects it. With
+webhook.existingSecret it is webhook.caBundle, or the ca.crt of the Secret when it can be looked up.
+Through the Ingress it is webhook.ingress.caBundle, empty when the API server trusts its certificate
+*/}}
+{{- define "[[ .ProjectName ]].webhookCABundle" -}}
+{{- if include "[[ .ProjectName ]].webhookIngress" . }}
+{{- .Values.webhook.ingress.caBundle }}
+{{- else if .Values.webhook.existingSecret }}
+{{- if .Values.webhook.caBundle }}
+{{- .Values.webhook.caBundle }}
+{{- else }}
+{{- $secret := lookup "v1" "Secret" .Release.Namespace .Values.webhook.existingSecret }}
+{{- if $secret }}
+{{- index $secret.data "ca.crt" }}
+{{- end }}
+{{- end }}
+[[- if .GenerateCerts ]]
+{{- else }}
+{{- (include "[[ .ProjectName ]].webhookCerts" . | fromYaml).ca | b64enc }}
+[[- end ]]
+{{- end }}
 {{- end }}
 `
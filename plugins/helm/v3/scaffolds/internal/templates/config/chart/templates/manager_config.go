@@ -0,0 +1,68 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &ManagerConfig{}
+
+// ManagerConfig scaffolds a file that defines the ConfigMap the manager loads its flags from
+type ManagerConfig struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *ManagerConfig) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "manager-config.yaml")
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = managerConfigTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const managerConfigTemplate = `{{- if .Values.managerConfig.enabled -}}
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-manager-config
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+data:
+  controller_manager_config.yaml: |
+    {{- include "[[ .ProjectName ]].managerFlags" . | nindent 4 }}
+{{- end }}
+`
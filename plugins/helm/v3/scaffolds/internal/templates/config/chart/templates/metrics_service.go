@@ -19,25 +19,27 @@ package templates
 import (
 	"path/filepath"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
-var _ machinery.Template = &MonitorService{}
+var _ machinery.Template = &MetricsService{}
 
-// MonitorService scaffolds a file that defines the monitor service
-type MonitorService struct {
+// MetricsService scaffolds a file that defines the Service exposing the manager metrics
+type MetricsService struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 	Force bool
 }
 
 // SetTemplateDefaults implements file.Template
-func (f *MonitorService) SetTemplateDefaults() error {
+func (f *MetricsService) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "monitor-service.yaml")
+		f.Path = filepath.Join(f.ChartDir, "templates", "metrics-service.yaml")
 	}
 	f.SetDelim("[[", "]]")
-	f.TemplateBody = monitorServiceTemplate
+	f.TemplateBody = metricsServiceTemplate
 
 	if f.Force {
 		f.IfExistsAction = machinery.OverwriteFile
@@ -48,20 +50,27 @@ func (f *MonitorService) SetTemplateDefaults() error {
 	return nil
 }
 
-const monitorServiceTemplate = `{{- if .Values.prometheus -}}
-apiVersion: v1
+const metricsServiceTemplate = `apiVersion: v1
 kind: Service
 metadata:
-  name: {{ include "[[ .ProjectName ]].fullname" . }}-monitor-service
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-metrics-service
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 spec:
   ports:
-    - port: 8443
+    - name: {{ .Values.metrics.service.portName }}
+      port: {{ .Values.metrics.service.port }}
+      {{- if include "[[ .ProjectName ]].metricsRBACProxy" . }}
+      # The kube-rbac-proxy sidecar serving the metrics of the manager over TLS
       targetPort: https
+      {{- else }}
+      targetPort: metrics
+      {{- end }}
       protocol: TCP
-      name: https
   selector:
     {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 4 }}
-{{- end }}
 `
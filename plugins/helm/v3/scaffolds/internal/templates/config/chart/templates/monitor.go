@@ -19,6 +19,7 @@ package templates
 import (
 	"path/filepath"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
@@ -28,13 +29,14 @@ var _ machinery.Template = &Monitor{}
 type Monitor struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 	Force bool
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *Monitor) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "monitor.yaml")
+		f.Path = filepath.Join(f.ChartDir, "templates", "monitor.yaml")
 	}
 	f.SetDelim("[[", "]]")
 
@@ -49,7 +51,7 @@ func (f *Monitor) SetTemplateDefaults() error {
 	return nil
 }
 
-const monitorTemplate = `{{- if .Values.prometheus -}}
+const monitorTemplate = `{{- if .Values.metrics.serviceMonitor.enabled -}}
 # Prometheus Monitor Service (Metrics)
 apiVersion: monitoring.coreos.com/v1
 kind: ServiceMonitor
@@ -57,16 +59,34 @@ metadata:
   name: {{ include "[[ .ProjectName ]].fullname" . }}-metrics
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 spec:
   endpoints:
     - path: /metrics
-      port: https
+      port: {{ .Values.metrics.service.portName }}
+      {{- if include "[[ .ProjectName ]].metricsRBACProxy" . }}
       scheme: https
       bearerTokenFile: /var/run/secrets/kubernetes.io/serviceaccount/token
       tlsConfig:
         insecureSkipVerify: true
+      {{- else }}
+      scheme: http
+      {{- end }}
+      {{- with .Values.metrics.serviceMonitor.interval }}
+      interval: {{ . }}
+      {{- end }}
+      {{- with .Values.metrics.serviceMonitor.scrapeTimeout }}
+      scrapeTimeout: {{ . }}
+      {{- end }}
+      {{- with .Values.metrics.serviceMonitor.relabelings }}
+      relabelings:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
   selector:
     matchLabels:
-      {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 4 }}
+      {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 6 }}
 {{- end }}
 `
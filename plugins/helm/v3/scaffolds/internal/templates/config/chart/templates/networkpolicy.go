@@ -0,0 +1,144 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &NetworkPolicy{}
+
+// NetworkPolicy scaffolds a file that defines the ingress, and optionally the egress, allowed to the manager pods
+type NetworkPolicy struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *NetworkPolicy) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "networkpolicy.yaml")
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = networkPolicyTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const networkPolicyTemplate = `{{- if .Values.networkPolicy.enabled -}}
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+spec:
+  podSelector:
+    matchLabels:
+      {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 6 }}
+  policyTypes:
+    - Ingress
+    {{- if .Values.networkPolicy.egress.enabled }}
+    - Egress
+  egress:
+    {{- include "[[ .ProjectName ]].networkPolicyEgress" . | nindent 4 }}
+    {{- end }}
+  ingress:
+    # Metrics are served by the kube-rbac-proxy sidecar, or by the manager without it.
+    - ports:
+        - port: {{ if include "[[ .ProjectName ]].metricsRBACProxy" . }}8443{{ else }}8080{{ end }}
+          protocol: TCP
+      from:
+        - namespaceSelector:
+            {{- toYaml .Values.networkPolicy.metrics.namespaceSelector | nindent 12 }}
+          podSelector:
+            {{- toYaml .Values.networkPolicy.metrics.podSelector | nindent 12 }}
+    {{- if include "[[ .ProjectName ]].managerWebhooks" . }}
+    - ports:
+        - port: {{ .Values.webhook.port }}
+          protocol: TCP
+      {{- with .Values.networkPolicy.webhook.apiServerCIDRs }}
+      from:
+        {{- range . }}
+        - ipBlock:
+            cidr: {{ . }}
+        {{- end }}
+      {{- end }}
+    {{- end }}
+{{- if include "[[ .ProjectName ]].webhookDeployment" . }}
+---
+apiVersion: networking.k8s.io/v1
+kind: NetworkPolicy
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-webhook
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+spec:
+  podSelector:
+    matchLabels:
+      {{- include "[[ .ProjectName ]].webhookSelectorLabels" . | nindent 6 }}
+  policyTypes:
+    - Ingress
+    {{- if .Values.networkPolicy.egress.enabled }}
+    - Egress
+  egress:
+    {{- include "[[ .ProjectName ]].networkPolicyEgress" . | nindent 4 }}
+    {{- end }}
+  ingress:
+    {{- if not (include "[[ .ProjectName ]].metricsRBACProxy" .) }}
+    # Metrics are served by the webhook servers without the kube-rbac-proxy sidecar only.
+    - ports:
+        - port: 8080
+          protocol: TCP
+      from:
+        - namespaceSelector:
+            {{- toYaml .Values.networkPolicy.metrics.namespaceSelector | nindent 12 }}
+          podSelector:
+            {{- toYaml .Values.networkPolicy.metrics.podSelector | nindent 12 }}
+    {{- end }}
+    - ports:
+        - port: {{ .Values.webhook.port }}
+          protocol: TCP
+      {{- with .Values.networkPolicy.webhook.apiServerCIDRs }}
+      from:
+        {{- range . }}
+        - ipBlock:
+            cidr: {{ . }}
+        {{- end }}
+      {{- end }}
+{{- end }}
+{{- end }}
+`
@@ -0,0 +1,89 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &Notes{}
+
+// Notes scaffolds a file that defines the post-install notes printed by helm
+type Notes struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	Force          bool
+	WebhookEnabled bool
+	// GenerateCerts indicates the webhook serving certificate is generated by helm instead of cert-manager
+	GenerateCerts bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *Notes) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "NOTES.txt")
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = notesTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const notesTemplate = `Thank you for installing {{ .Chart.Name }}.
+
+Your release is named {{ .Release.Name }} and the controller manager runs as the
+{{ include "[[ .ProjectName ]].fullname" . }} deployment in the {{ .Release.Namespace }} namespace.
+
+To check that the manager is running:
+
+  kubectl get pods -n {{ .Release.Namespace }} -l app.kubernetes.io/instance={{ .Release.Name }}
+[[- if .WebhookEnabled ]]
+{{- if include "[[ .ProjectName ]].webhookEnabled" . }}
+{{- if .Values.webhook.existingSecret }}
+
+The webhooks are served with the certificate of the {{ .Values.webhook.existingSecret }} secret, the manager
+only becomes ready once it exists, you can check it with:
+
+  kubectl get secret -n {{ .Release.Namespace }} {{ .Values.webhook.existingSecret }}
+{{- else }}
+[[- if .GenerateCerts ]]
+
+The webhooks are served with a self-signed certificate generated by helm and kept
+across upgrades, delete the secret and upgrade the release to rotate it:
+
+  kubectl delete secret -n {{ .Release.Namespace }} {{ include "[[ .ProjectName ]].fullname" . }}-webhook-server-cert
+[[- else ]]
+
+The webhooks are served with a certificate issued by cert-manager. The manager
+only becomes ready once the certificate is issued, you can check it with:
+
+  kubectl get certificate -n {{ .Release.Namespace }} {{ include "[[ .ProjectName ]].servingCertName" . }}
+[[- end ]]
+{{- end }}
+{{- end }}
+[[- end ]]
+`
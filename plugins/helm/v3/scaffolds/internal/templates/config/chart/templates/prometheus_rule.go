@@ -0,0 +1,126 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &PrometheusRule{}
+
+// PrometheusRule scaffolds a file that defines the prometheus alerting rules on the manager
+type PrometheusRule struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *PrometheusRule) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "prometheusrule.yaml")
+	}
+	f.SetDelim("[[", "]]")
+
+	f.TemplateBody = prometheusRuleTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a monitor was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+//nolint:lll
+const prometheusRuleTemplate = `{{- if .Values.metrics.prometheusRule.enabled -}}
+{{- $fullname := include "[[ .ProjectName ]].fullname" . }}
+{{- /* The scrapes of the ServiceMonitor and the PodMonitor are told apart by their job label */}}
+{{- $job := printf "%s-metrics-service" $fullname }}
+{{- if .Values.metrics.podMonitor.enabled }}
+{{- $job = printf "%s/%s-metrics" .Release.Namespace $fullname }}
+{{- end }}
+{{- $selector := printf "namespace=%q, job=%q" .Release.Namespace $job }}
+{{- $alerts := .Values.metrics.prometheusRule.alerts }}
+# Starter alerts on the manager keyed off the controller-runtime metrics
+apiVersion: monitoring.coreos.com/v1
+kind: PrometheusRule
+metadata:
+  name: {{ $fullname }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+    {{- with .Values.metrics.prometheusRule.labels }}
+    {{- toYaml . | nindent 4 }}
+    {{- end }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+spec:
+  groups:
+    - name: {{ $fullname }}
+      rules:
+        {{- with $alerts.managerDown }}
+        {{- if .enabled }}
+        - alert: ManagerDown
+          expr: |-
+            {{ printf "absent(up{%s} == 1)" $selector }}
+          for: {{ .for }}
+          labels:
+            severity: {{ .severity }}
+          annotations:
+            summary: "No manager of {{ $.Release.Namespace }}/{{ $fullname }} is up."
+            description: "Prometheus hasn't scraped any manager of {{ $.Release.Namespace }}/{{ $fullname }} for {{ .for }}, the custom resources aren't reconciled."
+        {{- end }}
+        {{- end }}
+        {{- with $alerts.reconcileErrors }}
+        {{- if .enabled }}
+        - alert: ReconcileErrorsHigh
+          expr: |-
+            {{ printf "sum by (controller) (rate(controller_runtime_reconcile_total{%s, result=\"error\"}[%s]))" $selector .window }}
+              {{ printf "/ sum by (controller) (rate(controller_runtime_reconcile_total{%s}[%s])) > %v" $selector .window .threshold }}
+          for: {{ .for }}
+          labels:
+            severity: {{ .severity }}
+          annotations:
+            summary: "The {{ "{{ $labels.controller }}" }} controller of {{ $.Release.Namespace }}/{{ $fullname }} fails to reconcile."
+            description: "{{ "{{ $value | humanizePercentage }}" }} of the reconciles of the {{ "{{ $labels.controller }}" }} controller end in error, above {{ .threshold }}."
+        {{- end }}
+        {{- end }}
+        {{- with $alerts.leaderElectionFlapping }}
+        {{- if .enabled }}
+        - alert: LeaderElectionFlapping
+          expr: |-
+            {{ printf "sum(changes(leader_election_master_status{%s}[%s])) > %v" $selector .window .threshold }}
+          for: {{ .for }}
+          labels:
+            severity: {{ .severity }}
+          annotations:
+            summary: "The leader of {{ $.Release.Namespace }}/{{ $fullname }} keeps changing."
+            description: "The leader election of {{ $.Release.Namespace }}/{{ $fullname }} changed {{ "{{ $value }}" }} times over {{ .window }}, e.g. as the leader fails to renew its Lease."
+        {{- end }}
+        {{- end }}
+        {{- with .Values.metrics.prometheusRule.additionalRules }}
+        {{- toYaml . | nindent 8 }}
+        {{- end }}
+{{- end }}
+`
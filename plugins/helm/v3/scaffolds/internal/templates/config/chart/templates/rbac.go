@@ -19,6 +19,7 @@ package templates
 import (
 	"path/filepath"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
@@ -28,6 +29,7 @@ var _ machinery.Template = &Rbac{}
 type Rbac struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 
 	Force bool
 }
@@ -35,7 +37,7 @@ type Rbac struct {
 // SetTemplateDefaults implements file.Template
 func (f *Rbac) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "rbac.yaml")
+		f.Path = filepath.Join(f.ChartDir, "templates", "rbac.yaml")
 	}
 
 	f.TemplateBody = rbacTemplate
@@ -49,45 +51,54 @@ func (f *Rbac) SetTemplateDefaults() error {
 	return nil
 }
 
-const rbacTemplate = `apiVersion: v1
+const rbacTemplate = `{{- if .Values.serviceAccount.create -}}
+apiVersion: v1
 kind: ServiceAccount
 metadata:
-  name: {{ include "[[ .ProjectName ]].fullname" . }}
+  name: {{ include "[[ .ProjectName ]].serviceAccountName" . }}
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with merge (dict) (.Values.serviceAccount.annotations | default dict) (include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") | fromYaml) }}
+  annotations:
+    {{- toYaml . | nindent 4 }}
+  {{- end }}
 ---
+{{- end }}
 apiVersion: rbac.authorization.k8s.io/v1
 kind: Role
 metadata:
   name: {{ include "[[ .ProjectName ]].fullname" . }}-role
+  namespace: {{ .Release.Namespace }}
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 rules:
-# Add leases roles.
+{{- if .Values.leaderElection.enabled }}
+# Add leader election roles, the Lease can't be restricted by name on creation.
 - apiGroups:
-  - ""
+  - coordination.k8s.io
   resources:
-  - configmaps
+  - leases
   verbs:
-  - get
-  - list
-  - watch
   - create
-  - update
-  - patch
-  - delete
 - apiGroups:
   - coordination.k8s.io
   resources:
   - leases
+  resourceNames:
+  - {{ include "[[ .ProjectName ]].leaderElectionID" . }}
   verbs:
   - get
   - list
   - watch
-  - create
   - update
   - patch
   - delete
+{{- end }}
+# The manager records Events whether or not it runs with leader election.
 - apiGroups:
   - ""
   resources:
@@ -97,11 +108,36 @@ rules:
   - patch
 ---
 apiVersion: rbac.authorization.k8s.io/v1
+kind: RoleBinding
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-rolebinding
+  namespace: {{ .Release.Namespace }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: Role
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-role
+subjects:
+- kind: ServiceAccount
+  name: {{ include "[[ .ProjectName ]].serviceAccountName" . }}
+  namespace: {{ .Release.Namespace }}
+---
+{{- if include "[[ .ProjectName ]].metricsRBACProxy" . }}
+apiVersion: rbac.authorization.k8s.io/v1
 kind: ClusterRole
 metadata:
   name: {{ include "[[ .ProjectName ]].fullname" . }}-cluster-role
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 rules:
 # Add proxy roles.
 - nonResourceURLs:
@@ -122,30 +158,60 @@ rules:
   - create
 ---
 apiVersion: rbac.authorization.k8s.io/v1
-kind: RoleBinding
+kind: ClusterRoleBinding
 metadata:
-  name: {{ include "[[ .ProjectName ]].fullname" . }}-rolebinding
-  namespace: {{ .Release.Namespace }}
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-clusterrolebinding
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 roleRef:
   apiGroup: rbac.authorization.k8s.io
-  kind: Role
-  name: {{ include "[[ .ProjectName ]].fullname" . }}-role
+  kind: ClusterRole
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-cluster-role
 subjects:
 - kind: ServiceAccount
-  name: {{ include "[[ .ProjectName ]].fullname" . }}
+  name: {{ include "[[ .ProjectName ]].serviceAccountName" . }}
   namespace: {{ .Release.Namespace }}
+{{- if .Values.metrics.rbac.create }}
+---
+# Grants the scrapes authorized by the kube-rbac-proxy sidecar access to the metrics.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-metrics-reader
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+rules:
+- nonResourceURLs:
+  - /metrics
+  verbs:
+  - get
+{{- if .Values.metrics.rbac.subjects }}
 ---
 apiVersion: rbac.authorization.k8s.io/v1
 kind: ClusterRoleBinding
 metadata:
-  name: {{ include "[[ .ProjectName ]].fullname" . }}-clusterrolebinding
-  namespace: {{ .Release.Namespace }}
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-metrics-reader
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 roleRef:
   apiGroup: rbac.authorization.k8s.io
   kind: ClusterRole
-  name: {{ include "[[ .ProjectName ]].fullname" . }}-cluster-role
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-metrics-reader
 subjects:
-- kind: ServiceAccount
-  name: {{ include "[[ .ProjectName ]].fullname" . }}
-  namespace: {{ .Release.Namespace }}
+  {{- toYaml .Values.metrics.rbac.subjects | nindent 2 }}
+{{- end }}
+{{- end }}
+{{- end }}
 `
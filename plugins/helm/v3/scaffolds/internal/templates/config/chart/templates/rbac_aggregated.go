@@ -0,0 +1,185 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &AggregatedRoles{}
+
+// AggregatedRoles scaffolds a file that defines the ClusterRoles aggregated to the admin, edit and view
+// ones of Kubernetes, the rules of each resource are inserted by AggregatedRolesUpdater.
+type AggregatedRoles struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+
+	// Force overwrites the file, dropping the rules added by hand, see AggregatedRolesUpdater
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *AggregatedRoles) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = aggregatedRolesPath(f.ChartDir)
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = fmt.Sprintf(aggregatedRolesTemplate,
+		machinery.NewMarkerFor(f.Path, editRulesMarker),
+		machinery.NewMarkerFor(f.Path, viewRulesMarker),
+	)
+
+	// The file accumulates the rules of every resource, so it is only overwritten when forced,
+	// the rules of the resources being inserted again afterwards.
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+// aggregatedRolesPath returns the path of the aggregated ClusterRoles in the chart of the project
+func aggregatedRolesPath(chartDir string) string {
+	return filepath.Join(chartDir, "templates", "rbac_aggregated.yaml")
+}
+
+var _ machinery.Inserter = &AggregatedRolesUpdater{}
+
+// AggregatedRolesUpdater inserts the rules of a resource in the aggregated ClusterRoles
+type AggregatedRolesUpdater struct {
+	chart.ChartDirMixin
+	machinery.ResourceMixin
+}
+
+// GetPath implements file.Builder
+func (f *AggregatedRolesUpdater) GetPath() string {
+	return aggregatedRolesPath(f.ChartDir)
+}
+
+// GetIfExistsAction implements file.Builder
+func (*AggregatedRolesUpdater) GetIfExistsAction() machinery.IfExistsAction {
+	return machinery.OverwriteFile
+}
+
+const (
+	editRulesMarker = "edit-rules"
+	viewRulesMarker = "view-rules"
+)
+
+// GetMarkers implements file.Inserter
+func (f *AggregatedRolesUpdater) GetMarkers() []machinery.Marker {
+	return []machinery.Marker{
+		machinery.NewMarkerFor(f.GetPath(), editRulesMarker),
+		machinery.NewMarkerFor(f.GetPath(), viewRulesMarker),
+	}
+}
+
+// The rules match the ones of the editor and viewer roles kubebuilder scaffolds for each resource.
+const (
+	editRulesCodeFragment = `- apiGroups:
+  - %[1]s
+  resources:
+  - %[2]s
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - %[1]s
+  resources:
+  - %[2]s/status
+  verbs:
+  - get
+`
+	viewRulesCodeFragment = `- apiGroups:
+  - %[1]s
+  resources:
+  - %[2]s
+  verbs:
+  - get
+  - list
+  - watch
+- apiGroups:
+  - %[1]s
+  resources:
+  - %[2]s/status
+  verbs:
+  - get
+`
+)
+
+// GetCodeFragments implements file.Inserter
+func (f *AggregatedRolesUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
+	fragments := make(machinery.CodeFragmentsMap, 2)
+
+	// If resource is not being provided we are creating the file, not updating it
+	if f.Resource == nil {
+		return fragments
+	}
+
+	fragments[machinery.NewMarkerFor(f.GetPath(), editRulesMarker)] = []string{
+		fmt.Sprintf(editRulesCodeFragment, f.Resource.QualifiedGroup(), f.Resource.Plural),
+	}
+	fragments[machinery.NewMarkerFor(f.GetPath(), viewRulesMarker)] = []string{
+		fmt.Sprintf(viewRulesCodeFragment, f.Resource.QualifiedGroup(), f.Resource.Plural),
+	}
+	return fragments
+}
+
+const aggregatedRolesTemplate = `{{- if .Values.rbac.aggregatedRoles.create -}}
+# Granted to the users bound to the admin or edit ClusterRoles of Kubernetes, e.g. in a namespace.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-aggregate-to-edit
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+    rbac.authorization.k8s.io/aggregate-to-admin: "true"
+    rbac.authorization.k8s.io/aggregate-to-edit: "true"
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+rules:
+%s
+---
+# Granted to the users bound to the view ClusterRole of Kubernetes, e.g. in a namespace.
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-aggregate-to-view
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+    rbac.authorization.k8s.io/aggregate-to-view: "true"
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+rules:
+%s
+{{- end }}
+`
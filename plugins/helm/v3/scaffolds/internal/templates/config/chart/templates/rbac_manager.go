@@ -0,0 +1,167 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &ManagerRole{}
+
+// ManagerRole scaffolds a file that defines the ClusterRole granting the manager access to
+// the project resources, the rules of each resource are inserted by ManagerRoleUpdater.
+type ManagerRole struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+
+	// Force overwrites the file, dropping the rules added by hand, see ManagerRoleUpdater
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *ManagerRole) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = managerRolePath(f.ChartDir)
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = fmt.Sprintf(managerRoleTemplate,
+		machinery.NewMarkerFor(f.Path, rulesMarker),
+	)
+
+	// The file accumulates the rules of every resource, so it is only overwritten when forced,
+	// the rules of the resources being inserted again afterwards.
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+// managerRolePath returns the path of the manager ClusterRole in the chart of the project
+func managerRolePath(chartDir string) string {
+	return filepath.Join(chartDir, "templates", "rbac_manager.yaml")
+}
+
+var _ machinery.Inserter = &ManagerRoleUpdater{}
+
+// ManagerRoleUpdater inserts the rules of a resource in the manager ClusterRole
+type ManagerRoleUpdater struct {
+	chart.ChartDirMixin
+	machinery.ResourceMixin
+}
+
+// GetPath implements file.Builder
+func (f *ManagerRoleUpdater) GetPath() string {
+	return managerRolePath(f.ChartDir)
+}
+
+// GetIfExistsAction implements file.Builder
+func (*ManagerRoleUpdater) GetIfExistsAction() machinery.IfExistsAction {
+	return machinery.OverwriteFile
+}
+
+const rulesMarker = "rules"
+
+// GetMarkers implements file.Inserter
+func (f *ManagerRoleUpdater) GetMarkers() []machinery.Marker {
+	return []machinery.Marker{
+		machinery.NewMarkerFor(f.GetPath(), rulesMarker),
+	}
+}
+
+// The rules match the +kubebuilder:rbac markers of the scaffolded controller.
+const resourceRulesCodeFragment = `- apiGroups:
+  - %[1]s
+  resources:
+  - %[2]s
+  verbs:
+  - create
+  - delete
+  - get
+  - list
+  - patch
+  - update
+  - watch
+- apiGroups:
+  - %[1]s
+  resources:
+  - %[2]s/status
+  verbs:
+  - get
+  - patch
+  - update
+- apiGroups:
+  - %[1]s
+  resources:
+  - %[2]s/finalizers
+  verbs:
+  - update
+`
+
+// GetCodeFragments implements file.Inserter
+func (f *ManagerRoleUpdater) GetCodeFragments() machinery.CodeFragmentsMap {
+	fragments := make(machinery.CodeFragmentsMap, 1)
+
+	// If resource is not being provided we are creating the file, not updating it
+	if f.Resource == nil {
+		return fragments
+	}
+
+	fragments[machinery.NewMarkerFor(f.GetPath(), rulesMarker)] = []string{
+		fmt.Sprintf(resourceRulesCodeFragment, f.Resource.QualifiedGroup(), f.Resource.Plural),
+	}
+	return fragments
+}
+
+const managerRoleTemplate = `apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-manager-role
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+rules:
+%s
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-manager-rolebinding
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "rbac") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-manager-role
+subjects:
+- kind: ServiceAccount
+  name: {{ include "[[ .ProjectName ]].serviceAccountName" . }}
+  namespace: {{ .Release.Namespace }}
+`
@@ -19,6 +19,7 @@ package templates
 import (
 	"path/filepath"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
@@ -28,6 +29,7 @@ var _ machinery.Template = &WebhookCertManagerCheck{}
 type WebhookCertManagerCheck struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 	machinery.RepositoryMixin
 	Force bool
 }
@@ -35,7 +37,7 @@ type WebhookCertManagerCheck struct {
 // SetTemplateDefaults implements file.Template
 func (f *WebhookCertManagerCheck) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "webhook-cert-manager-check.yaml")
+		f.Path = filepath.Join(f.ChartDir, "templates", "webhook-cert-manager-check.yaml")
 	}
 	f.SetDelim("[[", "]]")
 	f.TemplateBody = certManagerCheckTemplate
@@ -50,15 +52,20 @@ func (f *WebhookCertManagerCheck) SetTemplateDefaults() error {
 	return nil
 }
 
-const certManagerCheckTemplate = `{{- if include "[[ .ProjectName ]].webhookEnabled" . -}}
+const certManagerCheckTemplate = `{{- if and (include "[[ .ProjectName ]].webhookEnabled" .) (not .Values.webhook.existingSecret) -}}
 apiVersion: batch/v1
 kind: Job
 metadata:
   name: "{{ .Release.Name }}-cert-manager-check"
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
   annotations:
     "helm.sh/hook": pre-install,pre-upgrade
     "helm.sh/hook-weight": "-5"
     "helm.sh/hook-delete-policy": hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
 spec:
   template:
     metadata:
@@ -19,6 +19,7 @@ package templates
 import (
 	"path/filepath"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
@@ -28,13 +29,14 @@ var _ machinery.Template = &WebhookCertificate{}
 type WebhookCertificate struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 	Force bool
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *WebhookCertificate) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "webhook-certificate.yaml")
+		f.Path = filepath.Join(f.ChartDir, "templates", "webhook-certificate.yaml")
 	}
 	f.SetDelim("[[", "]]")
 	f.TemplateBody = certManagerTemplate
@@ -48,7 +50,8 @@ func (f *WebhookCertificate) SetTemplateDefaults() error {
 	return nil
 }
 
-const certManagerTemplate = `# The following manifests contain a self-signed issuer CR and a certificate CR.
+const certManagerTemplate = `{{- if and (include "[[ .ProjectName ]].webhookEnabled" .) (not .Values.webhook.existingSecret) -}}
+# The following manifests contain a self-signed issuer CR and a certificate CR.
 # More document can be found at https://docs.cert-manager.io
 # WARNING: Targets CertManager v1.0. Check https://cert-manager.io/docs/installation/upgrading/ for breaking changes.
 apiVersion: cert-manager.io/v1
@@ -57,15 +60,23 @@ metadata:
   name: {{ include "[[ .ProjectName ]].fullname" . }}-selfsigned-issuer
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "certificates") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 spec:
   selfSigned: {}
 ---
 apiVersion: cert-manager.io/v1
 kind: Certificate
 metadata:
-  name: {{ include "[[ .ProjectName ]].fullname" . }}-serving-cert
+  name: {{ include "[[ .ProjectName ]].servingCertName" . }}
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "certificates") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 spec:
   dnsNames:
   - {{ include "[[ .ProjectName ]].fullname" . }}-webhook-service.{{.Release.Namespace}}.svc
@@ -74,4 +85,5 @@ spec:
     kind: Issuer
     name: {{ include "[[ .ProjectName ]].fullname" . }}-selfsigned-issuer
   secretName: {{ include "[[ .ProjectName ]].fullname" . }}-webhook-server-cert
+{{- end }}
 `
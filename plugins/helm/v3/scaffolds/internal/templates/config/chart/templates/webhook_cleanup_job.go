@@ -0,0 +1,178 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &WebhookCleanup{}
+
+// WebhookCleanup scaffolds a helm post-delete hook deleting the webhook configurations left behind on uninstall
+type WebhookCleanup struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	Force bool
+
+	// MutatingWebhooks indicates whether a resource of the project has a defaulting webhook,
+	// i.e. whether the MutatingWebhookConfiguration exists
+	MutatingWebhooks bool
+	// ValidatingWebhooks indicates whether a resource of the project has a validating webhook,
+	// i.e. whether the ValidatingWebhookConfiguration exists
+	ValidatingWebhooks bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *WebhookCleanup) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "webhook-cleanup.yaml")
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = webhookCleanupTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const webhookCleanupTemplate = `{{- if and (include "[[ .ProjectName ]].webhookEnabled" .) .Values.webhook.cleanupOnDelete -}}
+{{- $name := printf "%s-webhook-cleanup" (include "[[ .ProjectName ]].fullname" .) -}}
+# A stale webhook configuration whose Service is gone rejects every request on the resources it matches,
+# so the ones helm failed to delete on uninstall are deleted by this hook.
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-delete
+    "helm.sh/hook-weight": "-5"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-delete
+    "helm.sh/hook-weight": "-5"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+rules:
+[[- if .MutatingWebhooks ]]
+- apiGroups:
+  - admissionregistration.k8s.io
+  resources:
+  - mutatingwebhookconfigurations
+  resourceNames:
+  - {{ include "[[ .ProjectName ]].fullname" . }}-mutating-webhook-cfg
+  verbs:
+  - get
+  - delete
+[[- end ]]
+[[- if .ValidatingWebhooks ]]
+- apiGroups:
+  - admissionregistration.k8s.io
+  resources:
+  - validatingwebhookconfigurations
+  resourceNames:
+  - {{ include "[[ .ProjectName ]].fullname" . }}-validating-webhook-cfg
+  verbs:
+  - get
+  - delete
+[[- end ]]
+---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRoleBinding
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-delete
+    "helm.sh/hook-weight": "-5"
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+roleRef:
+  apiGroup: rbac.authorization.k8s.io
+  kind: ClusterRole
+  name: {{ $name }}
+subjects:
+- kind: ServiceAccount
+  name: {{ $name }}
+  namespace: {{ .Release.Namespace }}
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: {{ $name }}
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  annotations:
+    "helm.sh/hook": post-delete
+    "helm.sh/hook-delete-policy": before-hook-creation,hook-succeeded
+    {{- with include "[[ .ProjectName ]].annotations" . }}
+    {{- . | nindent 4 }}
+    {{- end }}
+spec:
+  backoffLimit: 3
+  template:
+    metadata:
+      # Not the selector labels of the manager, the pod isn't part of its Service nor PodDisruptionBudget.
+      labels:
+        app: "{{ .Chart.Name }}"
+        release: "{{ .Release.Name }}"
+    spec:
+      restartPolicy: Never
+      serviceAccountName: {{ $name }}
+      {{- with .Values.imagePullSecrets }}
+      imagePullSecrets:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      containers:
+        - name: webhook-cleanup
+          image: "{{ .Values.webhook.cleanupImage.repository }}:{{ .Values.webhook.cleanupImage.tag }}"
+          imagePullPolicy: {{ .Values.webhook.cleanupImage.pullPolicy }}
+          args:
+            - delete
+            - --ignore-not-found
+            [[- if .MutatingWebhooks ]]
+            - mutatingwebhookconfiguration/{{ include "[[ .ProjectName ]].fullname" . }}-mutating-webhook-cfg
+            [[- end ]]
+            [[- if .ValidatingWebhooks ]]
+            - validatingwebhookconfiguration/{{ include "[[ .ProjectName ]].fullname" . }}-validating-webhook-cfg
+            [[- end ]]
+{{- end }}
+`
@@ -0,0 +1,193 @@
+/*
+Copyright 2022 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &WebhookDeployment{}
+
+// WebhookDeployment scaffolds a file that defines the Deployment serving the webhooks apart from the manager
+type WebhookDeployment struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+
+	Force bool
+	// HealthProbePathPrefix prefixes the /healthz and /readyz paths the manager serves its probes on
+	HealthProbePathPrefix string
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *WebhookDeployment) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "webhook-deployment.yaml")
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = webhookDeploymentTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const webhookDeploymentTemplate = `{{- if include "[[ .ProjectName ]].webhookDeployment" . -}}
+# The manager runs with --disable-controllers in these pods, they only serve the webhooks and are
+# scaled apart from the manager pods running the controllers.
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-webhook
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+spec:
+  replicas: {{ .Values.webhook.deployment.replicas }}
+  revisionHistoryLimit: {{ .Values.revisionHistoryLimit }}
+  selector:
+    matchLabels:
+      {{- include "[[ .ProjectName ]].webhookSelectorLabels" . | nindent 6 }}
+  template:
+    metadata:
+      {{- with .Values.podAnnotations }}
+      annotations:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      labels:
+        {{- include "[[ .ProjectName ]].webhookSelectorLabels" . | nindent 8 }}
+        {{- with .Values.commonLabels }}
+        {{- toYaml . | nindent 8 }}
+        {{- end }}
+    spec:
+      {{- with .Values.imagePullSecrets }}
+      imagePullSecrets:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      serviceAccountName: {{ include "[[ .ProjectName ]].serviceAccountName" . }}
+      {{- with include "[[ .ProjectName ]].priorityClassName" . }}
+      priorityClassName: {{ . }}
+      {{- end }}
+      terminationGracePeriodSeconds: {{ .Values.terminationGracePeriodSeconds }}
+      {{- with .Values.podSecurityContext }}
+      securityContext:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      containers:
+        - name: webhook
+          command:
+          - /manager
+          args:
+            {{- $flags := include "[[ .ProjectName ]].managerFlags" . | fromYaml }}
+            {{- $_ := set $flags "disable-controllers" true }}
+            {{- $_ := set $flags "leader-elect" false }}
+            {{- $_ := unset $flags "disable-webhooks" }}
+            {{- range $name, $value := $flags }}
+            - --{{ $name }}={{ $value }}
+            {{- end }}
+            {{- with .Values.main.extraArgs }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
+          securityContext:
+            {{- toYaml .Values.main.securityContext | nindent 12 }}
+          image: "{{ .Values.main.image.repository }}:{{ .Values.main.image.tag | default .Chart.AppVersion }}"
+          imagePullPolicy: {{ .Values.main.image.pullPolicy }}
+          ports:
+          - containerPort: 8081
+            name: health
+            protocol: TCP
+          {{- if not (include "[[ .ProjectName ]].metricsRBACProxy" .) }}
+          - containerPort: 8080
+            name: metrics
+            protocol: TCP
+          {{- end }}
+          - containerPort: {{ .Values.webhook.port }}
+            name: webhook-server
+            protocol: TCP
+          livenessProbe:
+            httpGet:
+              path: [[ .HealthProbePathPrefix ]]/healthz
+              port: health
+            {{- with .Values.livenessProbe }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
+          readinessProbe:
+            httpGet:
+              path: [[ .HealthProbePathPrefix ]]/readyz
+              port: health
+            {{- with .Values.readinessProbe }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
+          resources:
+            {{- toYaml (.Values.webhook.deployment.resources | default .Values.main.resources) | nindent 12 }}
+          {{- with .Values.extraEnv }}
+          env:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          {{- with .Values.extraEnvFrom }}
+          envFrom:
+            {{- toYaml . | nindent 12 }}
+          {{- end }}
+          volumeMounts:
+            - mountPath: /tmp/k8s-webhook-server/serving-certs
+              name: cert
+              readOnly: true
+            {{- with .Values.extraVolumeMounts }}
+            {{- toYaml . | nindent 12 }}
+            {{- end }}
+      {{- with .Values.nodeSelector }}
+      nodeSelector:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      {{- with .Values.affinity }}
+      affinity:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      {{- with .Values.tolerations }}
+      tolerations:
+        {{- toYaml . | nindent 8 }}
+      {{- end }}
+      {{- with .Values.topologySpreadConstraints }}
+      topologySpreadConstraints:
+        {{- range . }}
+        {{- $constraint := deepCopy . }}
+        {{- if not (hasKey $constraint "labelSelector") }}
+        {{- $_ := set $constraint "labelSelector" (dict "matchLabels" (include "[[ .ProjectName ]].webhookSelectorLabels" $ | fromYaml)) }}
+        {{- end }}
+        {{- list $constraint | toYaml | nindent 8 }}
+        {{- end }}
+      {{- end }}
+      volumes:
+        - name: cert
+          secret:
+            defaultMode: 420
+            secretName: {{ include "[[ .ProjectName ]].webhookCertSecretName" . }}
+        {{- with .Values.extraVolumes }}
+        {{- toYaml . | nindent 8 }}
+        {{- end }}
+{{- end }}
+`
@@ -0,0 +1,72 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package templates
+
+import (
+	"path/filepath"
+
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &WebhookSecret{}
+
+// WebhookSecret scaffolds a file that defines the webhook serving certificate generated by helm,
+// it replaces the cert-manager issuer and certificate
+type WebhookSecret struct {
+	machinery.TemplateMixin
+	machinery.ProjectNameMixin
+	chart.ChartDirMixin
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *WebhookSecret) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "templates", "webhook-secret.yaml")
+	}
+	f.SetDelim("[[", "]]")
+	f.TemplateBody = webhookSecretTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+	return nil
+}
+
+const webhookSecretTemplate = `{{- if and (include "[[ .ProjectName ]].webhookEnabled" .) (not .Values.webhook.existingSecret) -}}
+{{- $certs := include "[[ .ProjectName ]].webhookCerts" . | fromYaml -}}
+apiVersion: v1
+kind: Secret
+type: kubernetes.io/tls
+metadata:
+  name: {{ include "[[ .ProjectName ]].fullname" . }}-webhook-server-cert
+  labels:
+    {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "certificates") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
+data:
+  ca.crt: {{ $certs.ca | b64enc }}
+  tls.crt: {{ $certs.cert | b64enc }}
+  tls.key: {{ $certs.key | b64enc }}
+{{- end }}
+`
@@ -19,6 +19,7 @@ package templates
 import (
 	"path/filepath"
 
+	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 )
 
@@ -28,13 +29,14 @@ var _ machinery.Template = &WebhookService{}
 type WebhookService struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	chart.ChartDirMixin
 	Force bool
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *WebhookService) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "templates", "webhook-service.yaml")
+		f.Path = filepath.Join(f.ChartDir, "templates", "webhook-service.yaml")
 	}
 	f.SetDelim("[[", "]]")
 	f.TemplateBody = webhookServiceTemplate
@@ -48,18 +50,24 @@ func (f *WebhookService) SetTemplateDefaults() error {
 	return nil
 }
 
-const webhookServiceTemplate = `apiVersion: v1
+const webhookServiceTemplate = `{{- if include "[[ .ProjectName ]].webhookEnabled" . -}}
+apiVersion: v1
 kind: Service
 metadata:
   name: {{ include "[[ .ProjectName ]].fullname" . }}-webhook-service
   labels:
     {{- include "[[ .ProjectName ]].labels" . | nindent 4 }}
+  {{- with include "[[ .ProjectName ]].waveAnnotations" (list . "manager") }}
+  annotations:
+    {{- . | nindent 4 }}
+  {{- end }}
 spec:
   ports:
-    - port: 443
-      targetPort: 9443
+    - port: {{ .Values.webhook.service.port }}
+      targetPort: webhook-server
       protocol: TCP
       name: webhook
   selector:
-    {{- include "[[ .ProjectName ]].selectorLabels" . | nindent 4 }}
+    {{- include "[[ .ProjectName ]].webhookSelectorLabels" . | nindent 4 }}
+{{- end }}
 `
@@ -29,18 +29,35 @@ var _ machinery.Template = &Values{}
 type Values struct {
 	machinery.TemplateMixin
 	machinery.ProjectNameMixin
+	ChartNameMixin
+	ChartDirMixin
 	machinery.RepositoryMixin
 	Force            bool
 	GithubDockerRepo string
+	// Dependencies get a block of values passed through to their chart
+	Dependencies []Dependency
+	// MetricsAuth is the default authentication of the metrics endpoint, rbac-proxy or none
+	MetricsAuth string
+	// ImageRepository and ImageTag are the defaults of the manager image values, the repository is
+	// derived from the project repository and the tag is latest when ImageRepository is empty
+	ImageRepository string
+	ImageTag        string
+	// TemplatedCRDs indicates the CRDs are rendered as release resources, see the CRDs template
+	TemplatedCRDs bool
+	// PrometheusRules indicates the PrometheusRule template is scaffolded
+	PrometheusRules bool
 }
 
 // SetTemplateDefaults implements file.Template
 func (f *Values) SetTemplateDefaults() error {
 	if f.Path == "" {
-		f.Path = filepath.Join("config", f.ProjectName, "values.yaml")
+		f.Path = filepath.Join(f.ChartDir, "values.yaml")
 	}
 
 	f.GithubDockerRepo = strings.Join(strings.Split(f.Repo, "/")[:2], "/")
+	if f.ImageRepository == "" {
+		f.ImageRepository, f.ImageTag = f.GithubDockerRepo+"/"+f.ProjectName, "latest"
+	}
 
 	f.TemplateBody = valuesTemplate
 
@@ -54,35 +71,135 @@ func (f *Values) SetTemplateDefaults() error {
 	return nil
 }
 
-const valuesTemplate = `# Default values for {{ .ProjectName }}.
+const valuesTemplate = `# Default values for {{ .ChartName }}.
 # This is a YAML-formatted file.
 # Declare variables to be passed into your templates.
+# The "# --" comments above the keys follow the helm-docs convention, run helm-docs in the chart
+# directory to generate the values table of its README.
+
+# -- Number of manager pods, only the leader reconciles while the others stand by.
 replicaCount: 1
+# -- Strategy replacing the manager pods on upgrades, Recreate stops the old pod before the new
+# one starts so two managers never run side by side, rollingUpdate is ignored then.
+updateStrategy:
+  type: RollingUpdate
+  rollingUpdate:
+    maxUnavailable: 1
+# -- Number of old ReplicaSets of the manager and webhook Deployments kept to roll back to, the older ones
+# left by the upgrades are garbage collected.
+revisionHistoryLimit: 10
+# -- Secrets used to pull the manager and proxy images from private registries,
+# e.g. [{name: regcred}]
+imagePullSecrets: []
+# -- Overrides the chart name used in the resource names and the app.kubernetes.io/name label.
 nameOverride: ""
+# -- Overrides the full name prefixing the resource names, defaults to <release>-<chart name>.
 fullnameOverride: ""
+
+# -- Labels added to every resource of the chart, e.g. for cost allocation or ownership
+# policies. They are also set on the manager pods.
+commonLabels: {}
+# -- Annotations added to every resource of the chart.
+commonAnnotations: {}
+
+argocd:
+  syncWaves:
+    # -- Annotate the resources of the chart with argocd.argoproj.io/sync-wave so Argo CD applies the CRDs,
+    # then the RBAC, the webhook serving certificate, the manager and finally the webhook configurations,
+    # each wave once the previous one is healthy. The helm hooks keep their own ordering.
+    enabled: false
+    # -- Wave of the CRDs, the Namespace and the PriorityClass.
+    crds: -3
+    # -- Wave of the ServiceAccount, the roles and their bindings.
+    rbac: -2
+    # -- Wave of the webhook serving certificate, its Secret or cert-manager Issuer and Certificate.
+    certificates: -1
+    # -- Wave of the manager and webhook Deployments with their Services, ConfigMap, autoscalers,
+    # PodDisruptionBudget, NetworkPolicies and monitoring resources.
+    manager: 0
+    # -- Wave of the webhook configurations, applied once the webhook server is ready to answer the API server.
+    webhooks: 1
+
+namespace:
+  # -- Render the release namespace with its Pod Security admission labels, for tools applying the
+  # rendered manifests like Argo CD. helm install needs the namespace to exist beforehand and
+  # fails when it was created without the chart, e.g. by --create-namespace.
+  create: false
+  # -- Pod Security Standard enforced in the namespace, the manager pod satisfies restricted
+  # but the hook and test pods only baseline.
+  podSecurity: baseline
+  # -- Extra labels of the namespace, e.g. {pod-security.kubernetes.io/warn: restricted}
+  labels: {}
+{{- if .TemplatedCRDs }}
+
+crds:
+  # -- Render the CRDs as part of the release so they are upgraded with it.
+  install: true
+  # -- Keep the CRDs, and so every custom resource, when the release is uninstalled.
+  keep: true
+{{- end }}
+
+serviceAccount:
+  # -- Specifies whether a service account should be created
+  create: true
+  # -- Annotations to add to the service account, e.g. to bind it to a cloud identity with
+  # eks.amazonaws.com/role-arn (IRSA) or iam.gke.io/gcp-service-account (GKE Workload Identity).
+  annotations: {}
+  # -- The name of the service account to use.
+  # If not set and create is true, a name is generated using the fullname template
+  name: ""
+
+rbac:
+  aggregatedRoles:
+    # -- Create ClusterRoles aggregated to the admin, edit and view ones of Kubernetes, so the users
+    # granted them, e.g. by a namespace RoleBinding, can manage the custom resources of the project.
+    create: false
+
 main:
   image:
-    repository: {{ .GithubDockerRepo }}/{{ .ProjectName }}
+    # -- Repository of the manager image.
+    repository: {{ .ImageRepository }}
+    # -- Pull policy of the manager image.
     pullPolicy: IfNotPresent
-    tag: "latest"
-  resources: 
+    # -- Tag of the manager image, defaults to the appVersion of the chart when empty.
+    tag: "{{ .ImageTag }}"
+  # -- Args appended to the manager flags, e.g. ["--watch-namespaces=team-a"], for the flags
+  # the chart doesn't know of. Prefer managerConfig.extraFlags to override the ones it sets.
+  extraArgs: []
+  # -- Replace the /manager command and all of its args, e.g. to run the manager under delve with
+  # command: [/dlv] and args: [exec, /manager, --headless, --listen=:2345, --api-version=2, --, --leader-elect=false].
+  # The flags the chart sets from the values above and extraArgs are dropped then, and the probes
+  # fail while the debugger pauses the manager.
+  command: []
+  # -- Args of the command above, replacing the flags set by the chart when not empty.
+  args: []
+  # -- Resources of the manager container, configure them based on the project requirements.
+  # More info: https://kubernetes.io/docs/concepts/configuration/manage-resources-containers/
+  resources:
     limits:
-      cpu: 100m
-      memory: 128Mi
+      cpu: 500m
+      memory: 256Mi
     requests:
       cpu: 100m
       memory: 128Mi
+  # -- Security context of the manager container, the defaults satisfy the restricted Pod Security Standard.
   securityContext:
     allowPrivilegeEscalation: false
+    readOnlyRootFilesystem: true
+    runAsNonRoot: true
     capabilities:
       drop:
         - "ALL"
 
 proxy:
   image:
+    # -- Repository of the kube-rbac-proxy image.
     repository: gcr.io/kubebuilder/kube-rbac-proxy
+    # -- Pull policy of the kube-rbac-proxy image.
     pullPolicy: IfNotPresent
+    # -- Tag of the kube-rbac-proxy image.
     tag: "v0.13.0"
+  # -- Resources of the kube-rbac-proxy container.
   resources: 
     limits:
       cpu: 500m
@@ -90,37 +207,417 @@ proxy:
     requests:
       cpu: 5m
       memory: 64Mi
+  # -- Security context of the kube-rbac-proxy container, the defaults satisfy the restricted Pod Security Standard.
   securityContext:
     allowPrivilegeEscalation: false
+    readOnlyRootFilesystem: true
+    runAsNonRoot: true
     capabilities:
       drop:
         - "ALL"
 
-prometheus: false
+metrics:
+  # -- Either rbac-proxy, the manager then serves metrics on 127.0.0.1:8080 and they are exposed through
+  # the kube-rbac-proxy sidecar on the https port (8443), which only lets through the scrapes authorized
+  # to get /metrics, or none to expose the metrics of the manager on :8080 over plain HTTP.
+  auth: {{ .MetricsAuth }}
+  service:
+{{- if eq .MetricsAuth "none" }}
+    # -- Name of the metrics Service port, the ServiceMonitor scrapes the port by name.
+    portName: http
+    # -- Port of the metrics Service.
+    port: 8080
+{{- else }}
+    # -- Name of the metrics Service port, the ServiceMonitor scrapes the port by name.
+    portName: https
+    # -- Port of the metrics Service.
+    port: 8443
+{{- end }}
+  rbac:
+    # -- Create the metrics-reader ClusterRole granting get on /metrics, required by the scrapes
+    # through the kube-rbac-proxy sidecar.
+    create: true
+    # -- Subjects bound to the metrics-reader ClusterRole, e.g. the service account of Prometheus
+    # [{kind: ServiceAccount, name: prometheus-k8s, namespace: monitoring}]
+    subjects: []
+  serviceMonitor:
+    # -- Render a ServiceMonitor scraping the metrics Service, requires the Prometheus Operator
+    # CRDs to be installed in the cluster.
+    enabled: false
+    # -- Interval of the scrapes.
+    interval: 30s
+    # -- Timeout of the scrapes.
+    scrapeTimeout: 10s
+    # -- Relabelings applied to the scraped targets.
+    relabelings: []
+  podMonitor:
+    # -- Render a PodMonitor scraping the manager pods directly instead of the Service, for the
+    # Prometheus setups standardized on PodMonitors. Mutually exclusive with the serviceMonitor.
+    enabled: false
+    # -- Interval of the scrapes.
+    interval: 30s
+    # -- Timeout of the scrapes.
+    scrapeTimeout: 10s
+    # -- Relabelings applied to the scraped targets.
+    relabelings: []
+    # -- Key of a Secret holding a token allowed to get /metrics, sent to the kube-rbac-proxy sidecar
+    # as pods can't be scraped with the Prometheus service account token, e.g. {name: metrics-token, key: token}
+    bearerTokenSecret: {}
+{{- if .PrometheusRules }}
+  prometheusRule:
+    # -- Render a PrometheusRule with starter alerts on the manager, requires the Prometheus Operator
+    # CRDs to be installed in the cluster and the metrics to be scraped by the serviceMonitor or the podMonitor.
+    enabled: false
+    # -- Labels of the PrometheusRule, e.g. the ones matched by the ruleSelector of Prometheus.
+    labels: {}
+    alerts:
+      managerDown:
+        # -- Alert when no manager has been scraped successfully.
+        enabled: true
+        # -- Duration the condition holds before the alert fires.
+        for: 5m
+        # -- Severity label of the alert.
+        severity: critical
+      reconcileErrors:
+        # -- Alert when the ratio of the reconciles of a controller ending in error exceeds the threshold.
+        enabled: true
+        # -- Ratio of the reconciles ending in error, between 0 and 1.
+        threshold: 0.1
+        # -- Window the rates of the reconciles are computed over.
+        window: 5m
+        # -- Duration the condition holds before the alert fires.
+        for: 15m
+        # -- Severity label of the alert.
+        severity: warning
+      leaderElectionFlapping:
+        # -- Alert when the leader election of the managers changes more than threshold times over the window.
+        enabled: true
+        # -- Number of leader changes.
+        threshold: 3
+        # -- Window the leader changes are counted over.
+        window: 1h
+        # -- Duration the condition holds before the alert fires.
+        for: 5m
+        # -- Severity label of the alert.
+        severity: warning
+    # -- Rules appended to the starter alerts, e.g. on the custom metrics of the controllers.
+    additionalRules: []
+{{- end }}
+
+webhook:
+  # -- Serve the scaffolded webhooks, when disabled the manager runs with --disable-webhooks
+  # and neither the webhook configurations nor their Service and Certificate are rendered.
+  enabled: true
+  # -- Port the manager serves the webhooks on, passed with --webhook-port and targeted by the Service.
+  port: 9443
+  # Unset, each webhook keeps the timeoutSeconds and failurePolicy of its marker, which the API server
+  # defaults to 10 and Fail. Set, they override the ones of every webhook of the chart:
+  # timeoutSeconds is how long the API server waits for a webhook before applying the failurePolicy, at most
+  # 30, a slow webhook holding the API calls on the resources it matches for that long. failurePolicy is
+  # whether it rejects (Fail) or lets through (Ignore) the requests when a webhook can't be called, e.g.
+  # while the manager restarts, Ignore keeping the writes available but skipping the defaulting and validation.
+  # timeoutSeconds: 10
+  # failurePolicy: Fail
+  # -- Namespaces whose requests are sent to the webhooks, the system ones are left out so the cluster
+  # components keep working when the webhooks are down. Add the release namespace to the excluded ones
+  # when the webhooks match resources the manager pods depend on, e.g. Pods, not to deadlock their start.
+  namespaceSelector:
+    matchExpressions:
+      - key: kubernetes.io/metadata.name
+        operator: NotIn
+        values:
+          - kube-system
+          - kube-public
+          - kube-node-lease
+  # -- Labels of the objects whose requests are sent to the webhooks, all of them when empty.
+  objectSelector: {}
+  service:
+    # -- Port of the webhook Service called by the API server.
+    port: 443
+  ingress:
+    # -- Route the webhook calls through an Ingress, for the managed control planes that can't reach the pods
+    # directly. The webhook configurations then call https://<host>/<path> instead of the webhook Service. The
+    # ingress controller must forward them over HTTPS, e.g. with the nginx.ingress.kubernetes.io/backend-protocol:
+    # HTTPS annotation, and be let in by the NetworkPolicy when it is enabled.
+    enabled: false
+    # -- Class of the Ingress, the default one of the cluster when empty.
+    className: ""
+    # -- Annotations of the Ingress.
+    annotations: {}
+    # -- Host the API server calls the webhooks on.
+    host: ""
+    tls:
+      # -- Secret holding the certificate of the host, the default one of the ingress controller when empty.
+      secretName: ""
+    # -- Base64 encoded CA bundle verifying the certificate of the host, the API server trusts the system roots when empty.
+    caBundle: ""
+  deployment:
+    # -- Serve the webhooks from a Deployment of its own running the manager with --disable-controllers,
+    # so admission stays available under heavy reconcile load. The manager pods run with --disable-webhooks then.
+    enabled: false
+    # -- Number of webhook server pods, they don't take part in the leader election.
+    replicas: 2
+    # -- Resources of the webhook server container, defaults to the ones of the manager when empty.
+    resources: {}
+  # -- Name of a pre-created kubernetes.io/tls Secret serving the webhooks, with the ca.crt, tls.crt and
+  # tls.key keys, e.g. synced by external-secrets or unsealed by sealed-secrets. The chart then renders
+  # neither the serving certificate Secret nor the cert-manager Certificate.
+  existingSecret: ""
+  # -- Base64 encoded CA bundle of the existingSecret certificate set in the webhook configurations, read
+  # from its ca.crt key when empty. Set it when rendering with "helm template", e.g. by Argo CD, as the
+  # Secret can't be looked up then.
+  caBundle: ""
+  # -- Delete the webhook configurations on "helm uninstall" with a post-delete hook, in case helm
+  # left them behind as they would reject every request on the resources they match.
+  cleanupOnDelete: true
+  cleanupImage:
+    # -- Repository of the kubectl image run by the cleanup hook.
+    repository: bitnami/kubectl
+    # -- Pull policy of the kubectl image run by the cleanup hook.
+    pullPolicy: IfNotPresent
+    # -- Tag of the kubectl image run by the cleanup hook.
+    tag: "latest"
+  conversionImage:
+    # -- Repository of the kubectl image run by the hooks enabling the conversion webhooks of the CRDs.
+    repository: bitnami/kubectl
+    # -- Pull policy of the kubectl image run by the conversion hooks.
+    pullPolicy: IfNotPresent
+    # -- Tag of the kubectl image run by the conversion hooks.
+    tag: "latest"
 
 certManager:
+  # -- Service of the cert-manager webhook, checked to be reachable before the release is installed.
   domain: cert-manager-webhook.cert-manager.svc
+  # -- Port of the cert-manager webhook Service.
   port: 443
 
 logger:
-  #Development Mode
+  # -- Development Mode
   zap: true
-  #  Can be one of 'debug', 'info', 'error'
+  # -- Can be one of 'debug', 'info', 'error'
   level: info
+  # -- Encode logs as JSON, stacktraces are then only captured on panics
+  json: false
 
 rateLimiter:
+  # -- Base delay of the per-item exponential backoff of the failed reconciles.
   minRetryDelay: 5ms
+  # -- Maximum delay of the per-item exponential backoff of the failed reconciles.
   maxRetryDelay: 1000s
+  # -- Overall rate of the reconciles in queries per second.
   defaultQPS: 10.0
+  # -- Burst of the overall reconcile rate.
   defaultBurst: 100
+  # -- Maximum number of concurrent reconciles per controller.
   defaultConcurrent: 5
 
+managerConfig:
+  # -- Render the manager flags into a ConfigMap loaded with --config instead of container args.
+  enabled: false
+  # -- Additional manager flags keyed by name without the leading dashes, they override the
+  # ones set from the values above, e.g. {watch-namespaces: "team-a,team-b"}. A flag set to ""
+  # is omitted so the manager default applies, e.g. {health-probe-bind-address: ""}
+  extraFlags: {}
+
+# -- Extra environment variables of the manager container, e.g. the proxy settings of air-gapped installs
+# [{name: HTTPS_PROXY, value: "http://proxy:3128"}, {name: NO_PROXY, value: ".svc,10.0.0.0/8"}],
+# entries may use valueFrom to read a Secret or a ConfigMap key.
+extraEnv: []
+# -- Secrets and ConfigMaps whose keys are all exported to the manager container,
+# e.g. [{secretRef: {name: proxy-settings}}]
+extraEnvFrom: []
+
+# -- Timing of the liveness probe of the manager on its health port.
+livenessProbe:
+  initialDelaySeconds: 15
+  periodSeconds: 20
+  timeoutSeconds: 1
+  failureThreshold: 3
+# -- Timing of the readiness probe of the manager on its health port, raise the initial delays of
+# the managers whose caches take long to sync on startup.
+readinessProbe:
+  initialDelaySeconds: 5
+  periodSeconds: 10
+  timeoutSeconds: 1
+  failureThreshold: 3
+
+# -- Extra volumes of the manager pod, e.g. a CA bundle for its outbound TLS
+# [{name: ca-bundle, configMap: {name: corporate-ca}}]
+extraVolumes: []
+# -- Mounts of the extra volumes in the manager container, e.g.
+# [{name: ca-bundle, mountPath: /etc/ssl/certs/corporate-ca.crt, subPath: ca.crt, readOnly: true}]
+extraVolumeMounts: []
 
+# -- Sidecar containers added to the manager pod after the kube-rbac-proxy one, e.g. a log shipper.
+extraContainers: []
+
+# -- Feature gates of the manager passed with --feature-gates, e.g. {Foo: true, Bar: false}
+featureGates: {}
+
+leaderElection:
+  # -- Runs the manager with --leader-elect and grants it the Role to manage its Lease.
+  enabled: true
+  # -- Name of the Lease, overrides the one built into the manager so several releases of
+  # the same operator can run in one namespace.
+  id: ""
+
+autoscaling:
+  # -- Render a HorizontalPodAutoscaler of the manager. Leader election keeps a single active
+  # reconciler, extra replicas scaled by the autoscaler only run as standby managers ready to take over.
+  enabled: false
+  # -- Minimum number of manager pods.
+  minReplicas: 1
+  # -- Maximum number of manager pods.
+  maxReplicas: 3
+  # -- Average CPU utilization of the manager pods the autoscaler targets.
+  targetCPUUtilizationPercentage: 80
+
+verticalAutoscaling:
+  # -- Render a VerticalPodAutoscaler recommending the resources of the manager pod, see
+  # "kubectl describe vpa".
+  enabled: false
+  # -- Can be one of 'Off', 'Initial', 'Auto'. The Initial and Auto update modes also apply the
+  # recommendations, Auto evicting the pods to do so, don't combine them with the autoscaling
+  # above as both react to the CPU usage.
+  updateMode: "Off"
+
+podDisruptionBudget:
+  # -- Render a PodDisruptionBudget of the manager pods. Only one of minAvailable and
+  # maxUnavailable can be set, minAvailable defaults to 1 when neither is.
+  enabled: false
+  # minAvailable: 1
+  # maxUnavailable: 1
+
+networkPolicy:
+  # -- Render a NetworkPolicy only letting in the metrics scrapes and the webhook calls.
+  enabled: false
+  metrics:
+    # -- Namespaces allowed to scrape the metrics port.
+    namespaceSelector:
+      matchLabels:
+        kubernetes.io/metadata.name: monitoring
+    # -- Pods allowed to scrape the metrics port, any in the namespaces above when empty.
+    podSelector: {}
+  webhook:
+    # -- CIDRs of the API server allowed to call the webhooks, any source is allowed when empty.
+    apiServerCIDRs: []
+  egress:
+    # -- Also deny the egress of the manager pods but the DNS queries, the API server calls and the extraRules.
+    enabled: false
+    dns:
+      # -- Namespaces of the cluster DNS pods.
+      namespaceSelector:
+        matchLabels:
+          kubernetes.io/metadata.name: kube-system
+      # -- Cluster DNS pods.
+      podSelector:
+        matchLabels:
+          k8s-app: kube-dns
+    apiServer:
+      # -- CIDRs of the API server, e.g. the addresses of the kubernetes Endpoints of the default namespace,
+      # any destination is allowed on the ports below when empty.
+      cidrs: []
+      # -- Ports of the API server. Most CNIs apply the policies once the kubernetes Service is translated to
+      # its endpoints, so the port of the endpoints (e.g. 6443) has to be allowed along the Service one.
+      ports: [443, 6443]
+    # -- Egress rules appended to the ones above, e.g. to the external services called by the controllers
+    # [{to: [{ipBlock: {cidr: 10.0.0.0/8}}], ports: [{port: 5432, protocol: TCP}]}]
+    extraRules: []
+
+migration:
+  # -- Run a one-shot Job before the manager is installed or upgraded, e.g. to migrate the schema
+  # or the data of the operator. Helm waits for it to complete and fails the release when it doesn't.
+  enabled: false
+  image:
+    # -- Repository of the migration image, defaults to the manager image when empty.
+    repository: ""
+    # -- Pull policy of the migration image.
+    pullPolicy: IfNotPresent
+    # -- Tag of the migration image.
+    tag: ""
+  # -- Command of the migration container, e.g. [/manager]
+  command: []
+  # -- Args of the migration container, e.g. [migrate, --to=latest]
+  args: []
+  # -- Environment variables of the migration container.
+  env: []
+  # -- Resources of the migration container.
+  resources: {}
+  # -- Either Never to retry the migrations in new pods, or OnFailure to restart the same pod,
+  # up to backoffLimit times.
+  restartPolicy: Never
+  # -- Number of retries before the Job is considered failed.
+  backoffLimit: 3
+  # -- Deadline of the whole Job in seconds, none when 0.
+  activeDeadlineSeconds: 0
+  # -- The service account of the manager doesn't exist yet on install, the Job runs with the
+  # default one of the namespace unless set.
+  serviceAccountName: ""
+
+# Run by "helm test <release>" to check the manager Deployment becomes available.
+tests:
+  image:
+    # -- Repository of the kubectl image run by the tests.
+    repository: bitnami/kubectl
+    # -- Pull policy of the kubectl image run by the tests.
+    pullPolicy: IfNotPresent
+    # -- Tag of the kubectl image run by the tests.
+    tag: "latest"
+  # -- Time the tests wait for the manager Deployment to become available.
+  timeout: 120s
+
+# -- Annotations of the manager pods.
 podAnnotations: {}
 
+# -- Security context of the manager pod, the containers ones are under main and proxy.
+podSecurityContext:
+  runAsNonRoot: true
+  seccompProfile:
+    type: RuntimeDefault
+
+# -- Priority class of the manager pod so it isn't among the first evicted under resource pressure,
+# e.g. system-cluster-critical. Defaults to the PriorityClass below when it is created.
+priorityClassName: ""
+
+priorityClass:
+  # -- Create a PriorityClass named after the release for the manager pod.
+  create: false
+  # -- Value of the created PriorityClass.
+  value: 1000000
+
+# -- Seconds Kubernetes waits for the manager to drain, e.g. to run the finalizers in flight, before it
+# is killed. The manager waits up to its --graceful-shutdown-timeout, 30s by default in main.go, for its
+# controllers to stop: when they take longer than this period, raise it above that timeout or lower the
+# timeout through managerConfig.extraFlags, otherwise the manager is SIGKILLed in the middle of the cleanup.
+terminationGracePeriodSeconds: 10
+
+# -- Run the manager pods in the network namespace of their node, e.g. for CNI or network operators
+# reaching node-local services. The manager then binds its ports on the node: the webhook one (webhook.port),
+# the probes one (8081) and the metrics ones (8080, and 8443 with kube-rbac-proxy) must be free on it,
+# and two manager pods can't be scheduled on the same node.
+hostNetwork: false
+# -- DNS policy of the manager pods, set it to ClusterFirstWithHostNet along with hostNetwork to keep
+# resolving the cluster Services.
+dnsPolicy: ClusterFirst
+
+# -- Node labels of the manager pod, e.g. to keep it off spot or GPU node pools.
+# More info: https://kubernetes.io/docs/concepts/scheduling-eviction/assign-pod-node/
 nodeSelector: {}
 
+# -- Tolerations of the manager pod.
 tolerations: []
 
+# -- Affinity of the manager pod.
 affinity: {}
+
+# -- Spread the standby managers across failure domains, e.g.
+# [{maxSkew: 1, topologyKey: topology.kubernetes.io/zone, whenUnsatisfiable: ScheduleAnyway}],
+# the labelSelector defaults to the manager pods when unset.
+topologySpreadConstraints: []
+{{- range .Dependencies }}
+
+{{ .Name }}:
+  # -- Install the {{ .Name }} dependency chart, the other values of this section are passed through to it.
+  enabled: true
+{{- end }}
 `
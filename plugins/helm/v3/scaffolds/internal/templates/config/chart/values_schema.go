@@ -0,0 +1,593 @@
+/*
+Copyright 2023 cuisongliu@qq.com.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chart
+
+import (
+	"path/filepath"
+
+	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
+)
+
+var _ machinery.Template = &ValuesSchema{}
+
+// ValuesSchema scaffolds the JSON schema helm validates the chart values against,
+// it has to describe every key scaffolded in values.yaml.
+type ValuesSchema struct {
+	machinery.TemplateMixin
+	ChartNameMixin
+	ChartDirMixin
+
+	// Dependencies get a property holding the values passed through to their chart
+	Dependencies []Dependency
+	// TemplatedCRDs indicates the CRDs are rendered as release resources, see the CRDs template
+	TemplatedCRDs bool
+	// PrometheusRules indicates the PrometheusRule template is scaffolded
+	PrometheusRules bool
+
+	Force bool
+}
+
+// SetTemplateDefaults implements file.Template
+func (f *ValuesSchema) SetTemplateDefaults() error {
+	if f.Path == "" {
+		f.Path = filepath.Join(f.ChartDir, "values.schema.json")
+	}
+
+	f.TemplateBody = valuesSchemaTemplate
+
+	if f.Force {
+		f.IfExistsAction = machinery.OverwriteFile
+	} else {
+		// If file exists (ex. because a webhook was already created), skip creation.
+		f.IfExistsAction = machinery.SkipFile
+	}
+
+	return nil
+}
+
+const valuesSchemaTemplate = `{
+  "$schema": "http://json-schema.org/draft-07/schema#",
+  "title": "Values of the {{ .ChartName }} chart",
+  "type": "object",
+  "additionalProperties": false,
+  "definitions": {
+    "image": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["repository"],
+      "properties": {
+        "repository": {"type": "string", "minLength": 1},
+        "pullPolicy": {"type": "string", "enum": ["Always", "IfNotPresent", "Never"]},
+        "tag": {"type": "string"}
+      }
+    },
+    "container": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["image"],
+      "properties": {
+        "image": {"$ref": "#/definitions/image"},
+        "resources": {"type": "object"},
+        "securityContext": {"type": "object"}
+      }
+    },
+    "probe": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "initialDelaySeconds": {"type": "integer", "minimum": 0},
+        "periodSeconds": {"type": "integer", "minimum": 1},
+        "timeoutSeconds": {"type": "integer", "minimum": 1},
+        "failureThreshold": {"type": "integer", "minimum": 1}
+      }
+    },
+    "duration": {"type": "string", "pattern": "^([0-9]+(\\.[0-9]+)?(ns|us|µs|ms|s|m|h))+$"},
+    "intOrPercent": {
+      "anyOf": [
+        {"type": "integer", "minimum": 0},
+        {"type": "string", "pattern": "^[0-9]+%$"}
+      ]
+    }
+  },
+  "properties": {
+    "replicaCount": {"type": "integer", "minimum": 1},
+    "updateStrategy": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "type": {"enum": ["RollingUpdate", "Recreate"]},
+        "rollingUpdate": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "maxUnavailable": {"$ref": "#/definitions/intOrPercent"},
+            "maxSurge": {"$ref": "#/definitions/intOrPercent"}
+          }
+        }
+      }
+    },
+    "revisionHistoryLimit": {"type": "integer", "minimum": 0},
+    "imagePullSecrets": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {"name": {"type": "string"}}
+      }
+    },
+    "nameOverride": {"type": "string"},
+    "fullnameOverride": {"type": "string"},
+    "commonLabels": {"type": "object", "additionalProperties": {"type": "string"}},
+    "commonAnnotations": {"type": "object", "additionalProperties": {"type": "string"}},
+    "argocd": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "syncWaves": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {"type": "boolean"},
+            "crds": {"type": "integer"},
+            "rbac": {"type": "integer"},
+            "certificates": {"type": "integer"},
+            "manager": {"type": "integer"},
+            "webhooks": {"type": "integer"}
+          }
+        }
+      }
+    },
+    "namespace": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "create": {"type": "boolean"},
+        "podSecurity": {"type": "string", "enum": ["privileged", "baseline", "restricted"]},
+        "labels": {"type": "object", "additionalProperties": {"type": "string"}}
+      }
+    },
+{{- if .TemplatedCRDs }}
+    "crds": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "install": {"type": "boolean"},
+        "keep": {"type": "boolean"}
+      }
+    },
+{{- end }}
+    "serviceAccount": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "create": {"type": "boolean"},
+        "annotations": {"type": "object", "additionalProperties": {"type": "string"}},
+        "name": {"type": "string"}
+      }
+    },
+    "rbac": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "aggregatedRoles": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "create": {"type": "boolean"}
+          }
+        }
+      }
+    },
+    "main": {
+      "type": "object",
+      "additionalProperties": false,
+      "required": ["image"],
+      "properties": {
+        "image": {"$ref": "#/definitions/image"},
+        "resources": {"type": "object"},
+        "securityContext": {"type": "object"},
+        "extraArgs": {"type": "array", "items": {"type": "string"}},
+        "command": {"type": "array", "items": {"type": "string"}},
+        "args": {"type": "array", "items": {"type": "string"}}
+      }
+    },
+    "proxy": {"$ref": "#/definitions/container"},
+    "metrics": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "auth": {"type": "string", "enum": ["rbac-proxy", "none"]},
+        "service": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "portName": {"type": "string", "enum": ["https", "http"]},
+            "port": {"type": "integer", "minimum": 1, "maximum": 65535}
+          }
+        },
+        "rbac": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "create": {"type": "boolean"},
+            "subjects": {
+              "type": "array",
+              "items": {
+                "type": "object",
+                "required": ["kind", "name"],
+                "properties": {
+                  "kind": {"type": "string", "enum": ["ServiceAccount", "User", "Group"]},
+                  "name": {"type": "string"},
+                  "namespace": {"type": "string"},
+                  "apiGroup": {"type": "string"}
+                }
+              }
+            }
+          }
+        },
+        "serviceMonitor": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {"type": "boolean"},
+            "interval": {"$ref": "#/definitions/duration"},
+            "scrapeTimeout": {"$ref": "#/definitions/duration"},
+            "relabelings": {"type": "array", "items": {"type": "object"}}
+          }
+        },
+        "podMonitor": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {"type": "boolean"},
+            "interval": {"$ref": "#/definitions/duration"},
+            "scrapeTimeout": {"$ref": "#/definitions/duration"},
+            "relabelings": {"type": "array", "items": {"type": "object"}},
+            "bearerTokenSecret": {
+              "type": "object",
+              "additionalProperties": false,
+              "properties": {
+                "name": {"type": "string"},
+                "key": {"type": "string"},
+                "optional": {"type": "boolean"}
+              }
+            }
+          }
+        }
+        {{- if .PrometheusRules }},
+        "prometheusRule": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {"type": "boolean"},
+            "labels": {"type": "object", "additionalProperties": {"type": "string"}},
+            "alerts": {
+              "type": "object",
+              "additionalProperties": false,
+              "properties": {
+                "managerDown": {
+                  "type": "object",
+                  "additionalProperties": false,
+                  "properties": {
+                    "enabled": {"type": "boolean"},
+                    "for": {"$ref": "#/definitions/duration"},
+                    "severity": {"type": "string"}
+                  }
+                },
+                "reconcileErrors": {
+                  "type": "object",
+                  "additionalProperties": false,
+                  "properties": {
+                    "enabled": {"type": "boolean"},
+                    "threshold": {"type": "number", "minimum": 0, "maximum": 1},
+                    "window": {"$ref": "#/definitions/duration"},
+                    "for": {"$ref": "#/definitions/duration"},
+                    "severity": {"type": "string"}
+                  }
+                },
+                "leaderElectionFlapping": {
+                  "type": "object",
+                  "additionalProperties": false,
+                  "properties": {
+                    "enabled": {"type": "boolean"},
+                    "threshold": {"type": "integer", "minimum": 0},
+                    "window": {"$ref": "#/definitions/duration"},
+                    "for": {"$ref": "#/definitions/duration"},
+                    "severity": {"type": "string"}
+                  }
+                }
+              }
+            },
+            "additionalRules": {"type": "array", "items": {"type": "object"}}
+          }
+        }
+        {{- end }}
+      }
+    },
+    "webhook": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "port": {"type": "integer", "minimum": 1, "maximum": 65535},
+        "timeoutSeconds": {"type": "integer", "minimum": 1, "maximum": 30},
+        "failurePolicy": {"type": "string", "enum": ["Fail", "Ignore"]},
+        "namespaceSelector": {"type": "object"},
+        "objectSelector": {"type": "object"},
+        "service": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "port": {"type": "integer", "minimum": 1, "maximum": 65535}
+          }
+        },
+        "ingress": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {"type": "boolean"},
+            "className": {"type": "string"},
+            "annotations": {"type": "object", "additionalProperties": {"type": "string"}},
+            "host": {"type": "string"},
+            "tls": {
+              "type": "object",
+              "additionalProperties": false,
+              "properties": {
+                "secretName": {"type": "string"}
+              }
+            },
+            "caBundle": {"type": "string"}
+          }
+        },
+        "deployment": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {"type": "boolean"},
+            "replicas": {"type": "integer", "minimum": 1},
+            "resources": {"type": "object"}
+          }
+        },
+        "existingSecret": {"type": "string"},
+        "caBundle": {"type": "string"},
+        "cleanupOnDelete": {"type": "boolean"},
+        "cleanupImage": {"$ref": "#/definitions/image"},
+        "conversionImage": {"$ref": "#/definitions/image"}
+      }
+    },
+    "certManager": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "domain": {"type": "string"},
+        "port": {"type": "integer", "minimum": 1, "maximum": 65535}
+      }
+    },
+    "logger": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "zap": {"type": "boolean"},
+        "level": {
+          "anyOf": [
+            {"type": "string", "enum": ["debug", "info", "error"]},
+            {"type": "integer", "minimum": 1}
+          ]
+        },
+        "json": {"type": "boolean"}
+      }
+    },
+    "rateLimiter": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "minRetryDelay": {"$ref": "#/definitions/duration"},
+        "maxRetryDelay": {"$ref": "#/definitions/duration"},
+        "defaultQPS": {"type": "number", "exclusiveMinimum": 0},
+        "defaultBurst": {"type": "integer", "minimum": 1},
+        "defaultConcurrent": {"type": "integer", "minimum": 1}
+      }
+    },
+    "managerConfig": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "extraFlags": {
+          "type": "object",
+          "additionalProperties": {"type": ["string", "number", "boolean"]}
+        }
+      }
+    },
+    "migration": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "image": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "repository": {"type": "string"},
+            "pullPolicy": {"type": "string", "enum": ["Always", "IfNotPresent", "Never"]},
+            "tag": {"type": "string"}
+          }
+        },
+        "command": {"type": "array", "items": {"type": "string"}},
+        "args": {"type": "array", "items": {"type": "string"}},
+        "env": {"type": "array", "items": {"type": "object", "required": ["name"]}},
+        "resources": {"type": "object"},
+        "restartPolicy": {"type": "string", "enum": ["Never", "OnFailure"]},
+        "backoffLimit": {"type": "integer", "minimum": 0},
+        "activeDeadlineSeconds": {"type": "integer", "minimum": 0},
+        "serviceAccountName": {"type": "string"}
+      }
+    },
+    "livenessProbe": {"$ref": "#/definitions/probe"},
+    "readinessProbe": {"$ref": "#/definitions/probe"},
+    "extraEnv": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {"name": {"type": "string", "minLength": 1}}
+      }
+    },
+    "extraEnvFrom": {"type": "array", "items": {"type": "object"}},
+    "extraVolumes": {
+      "type": "array",
+      "items": {"type": "object", "required": ["name"]}
+    },
+    "extraVolumeMounts": {
+      "type": "array",
+      "items": {"type": "object", "required": ["name", "mountPath"]}
+    },
+    "extraContainers": {
+      "type": "array",
+      "items": {
+        "type": "object",
+        "required": ["name"],
+        "properties": {"name": {"type": "string", "minLength": 1}}
+      }
+    },
+    "featureGates": {
+      "type": "object",
+      "additionalProperties": {"type": "boolean"}
+    },
+    "leaderElection": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "id": {"type": "string", "pattern": "^([a-z0-9]([-a-z0-9]*[a-z0-9])?(\\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*)?$"}
+      }
+    },
+    "autoscaling": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "minReplicas": {"type": "integer", "minimum": 1},
+        "maxReplicas": {"type": "integer", "minimum": 1},
+        "targetCPUUtilizationPercentage": {"type": "integer", "minimum": 1, "maximum": 100}
+      }
+    },
+    "verticalAutoscaling": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "updateMode": {"type": "string", "enum": ["Off", "Initial", "Auto"]}
+      }
+    },
+    "podDisruptionBudget": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "minAvailable": {"$ref": "#/definitions/intOrPercent"},
+        "maxUnavailable": {"$ref": "#/definitions/intOrPercent"}
+      }
+    },
+    "networkPolicy": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "enabled": {"type": "boolean"},
+        "metrics": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "namespaceSelector": {"type": "object"},
+            "podSelector": {"type": "object"}
+          }
+        },
+        "webhook": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "apiServerCIDRs": {"type": "array", "items": {"type": "string"}}
+          }
+        },
+        "egress": {
+          "type": "object",
+          "additionalProperties": false,
+          "properties": {
+            "enabled": {"type": "boolean"},
+            "dns": {
+              "type": "object",
+              "additionalProperties": false,
+              "properties": {
+                "namespaceSelector": {"type": "object"},
+                "podSelector": {"type": "object"}
+              }
+            },
+            "apiServer": {
+              "type": "object",
+              "additionalProperties": false,
+              "properties": {
+                "cidrs": {"type": "array", "items": {"type": "string"}},
+                "ports": {
+                  "type": "array",
+                  "minItems": 1,
+                  "items": {"type": "integer", "minimum": 1, "maximum": 65535}
+                }
+              }
+            },
+            "extraRules": {"type": "array", "items": {"type": "object"}}
+          }
+        }
+      }
+    },
+    "tests": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "image": {"$ref": "#/definitions/image"},
+        "timeout": {"$ref": "#/definitions/duration"}
+      }
+    },
+    "podSecurityContext": {"type": "object"},
+    "podAnnotations": {"type": "object", "additionalProperties": {"type": "string"}},
+    "priorityClassName": {"type": "string"},
+    "terminationGracePeriodSeconds": {"type": "integer", "minimum": 0},
+    "hostNetwork": {"type": "boolean"},
+    "dnsPolicy": {"type": "string", "enum": ["ClusterFirst", "ClusterFirstWithHostNet", "Default"]},
+    "priorityClass": {
+      "type": "object",
+      "additionalProperties": false,
+      "properties": {
+        "create": {"type": "boolean"},
+        "value": {"type": "integer", "maximum": 1000000000}
+      }
+    },
+    "nodeSelector": {"type": "object", "additionalProperties": {"type": "string"}},
+    "tolerations": {"type": "array", "items": {"type": "object"}},
+    "affinity": {"type": "object"},
+    "topologySpreadConstraints": {"type": "array", "items": {"type": "object"}},
+    "global": {"type": "object"}
+{{- range .Dependencies }},
+    "{{ .Name }}": {
+      "type": "object",
+      "properties": {
+        "enabled": {"type": "boolean"}
+      }
+    }
+{{- end }}
+  }
+}
+`
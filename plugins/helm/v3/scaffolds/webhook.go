@@ -19,6 +19,7 @@ package scaffolds
 import (
 	"fmt"
 
+	pluginsdk "github.com/labring/kubebuilder4helm/plugin"
 	templates2 "github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds/internal/templates/config/chart/templates"
 	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
@@ -28,6 +29,21 @@ import (
 
 var _ plugins.Scaffolder = &webhookScaffolder{}
 
+const (
+	// CertManagerProvider issues the webhook serving certificate with cert-manager
+	CertManagerProvider = "cert-manager"
+	// HelmCertProvider generates the webhook serving certificate with the helm genCA and genSignedCert functions
+	HelmCertProvider = "helm"
+)
+
+// WebhookOptions are the options the webhook templates are scaffolded with, stored in the PROJECT file
+type WebhookOptions struct {
+	// CertProvider is either CertManagerProvider or HelmCertProvider
+	CertProvider string
+	// SkipCertManagerCheck indicates whether to skip the hook waiting for cert-manager, see CertManagerProvider
+	SkipCertManagerCheck bool
+}
+
 type webhookScaffolder struct {
 	config   config.Config
 	resource resource.Resource
@@ -37,14 +53,21 @@ type webhookScaffolder struct {
 
 	// force indicates whether to scaffold files even if they exist.
 	force bool
+
+	// opts are the options of the chart stored in the PROJECT file, see InitOptions
+	opts        InitOptions
+	webhookOpts WebhookOptions
 }
 
 // NewWebhookScaffolder returns a new Scaffolder for v2 webhook creation operations
-func NewWebhookScaffolder(config config.Config, resource resource.Resource, force bool) plugins.Scaffolder {
+func NewWebhookScaffolder(config config.Config, resource resource.Resource, force bool, opts InitOptions,
+	webhookOpts WebhookOptions) plugins.Scaffolder {
 	return &webhookScaffolder{
-		config:   config,
-		resource: resource,
-		force:    force,
+		config:      config,
+		resource:    resource,
+		force:       force,
+		opts:        opts,
+		webhookOpts: webhookOpts,
 	}
 }
 
@@ -65,19 +88,81 @@ func (s *webhookScaffolder) Scaffold() error {
 		return fmt.Errorf("error updating resource: %w", err)
 	}
 
-	if err := scaffold.Execute(
-		&templates2.Helpers{Force: true, WebhookEnabled: true},
-		&templates2.WebhookCertManagerCheck{Force: s.force},
-		&templates2.WebhookService{Force: s.force},
-		&templates2.WebhookCertificate{Force: s.force},
+	generateCerts := s.webhookOpts.CertProvider == HelmCertProvider
+	builders := []machinery.Builder{
+		&templates2.Helpers{Force: true, WebhookEnabled: true, GenerateCerts: generateCerts,
+			LeaderElectionID: s.opts.LeaderElectionID},
+		&templates2.Notes{Force: true, WebhookEnabled: true, GenerateCerts: generateCerts},
+	}
+	webhookBuilders, err := webhookTemplates(s.config, s.force, s.webhookOpts, s.opts.HealthProbePathPrefix)
+	if err != nil {
+		return err
+	}
+	builders = append(builders, webhookBuilders...)
+	if err := pluginsdk.Execute(scaffold, injectChartDir(s.opts.ChartDir, builders...)...); err != nil {
+		return fmt.Errorf("error scaffolding helm webhook manifests: %v", err)
+	}
+
+	if s.resource.HasConversionWebhook() {
+		if err := pluginsdk.Execute(scaffold, injectChartDir(s.opts.ChartDir,
+			&templates2.CRDConversion{Force: s.force, GenerateCerts: generateCerts},
+		)...); err != nil {
+			return fmt.Errorf("error scaffolding helm conversion webhook manifests: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// webhookTemplates returns the builders of the chart templates serving the webhooks of the project besides
+// the helpers and notes, the ones users may edit are only overwritten with force
+func webhookTemplates(c config.Config, force bool, webhookOpts WebhookOptions,
+	healthProbePathPrefix string) ([]machinery.Builder, error) {
+	mutatingWebhooks, validatingWebhooks, err := admissionWebhooks(c)
+	if err != nil {
+		return nil, err
+	}
+
+	builders := []machinery.Builder{
+		&templates2.WebhookService{Force: force},
+		&templates2.WebhookIngress{Force: force},
+		&templates2.WebhookDeployment{Force: force, HealthProbePathPrefix: healthProbePathPrefix},
 		//&kdefault.WebhookCAInjectionPatch{},
 		//&kdefault.ManagerWebhookPatch{},
 		//&webhook.KustomizeConfig{},
 
 		//&certmanager.KustomizeConfig{},
-	); err != nil {
-		return fmt.Errorf("error scaffolding helm webhook manifests: %v", err)
 	}
+	if mutatingWebhooks || validatingWebhooks {
+		// Regenerated as the webhook configurations to delete depend on the webhooks of every resource
+		builders = append(builders, &templates2.WebhookCleanup{Force: true,
+			MutatingWebhooks: mutatingWebhooks, ValidatingWebhooks: validatingWebhooks})
+	}
+	if webhookOpts.CertProvider == HelmCertProvider {
+		builders = append(builders, &templates2.WebhookSecret{Force: force})
+	} else {
+		if !webhookOpts.SkipCertManagerCheck {
+			builders = append(builders, &templates2.WebhookCertManagerCheck{Force: force})
+		}
+		builders = append(builders, &templates2.WebhookCertificate{Force: force})
+	}
+	return builders, nil
+}
 
-	return nil
+// admissionWebhooks returns whether any resource of the project has a defaulting webhook, i.e. whether
+// controller-gen generates the MutatingWebhookConfiguration, and whether any has a validating one,
+// i.e. whether it generates the ValidatingWebhookConfiguration
+func admissionWebhooks(c config.Config) (mutating, validating bool, err error) {
+	resources, err := c.GetResources()
+	if err != nil {
+		return false, false, fmt.Errorf("error getting resources: %w", err)
+	}
+	for _, res := range resources {
+		if res.Webhooks == nil {
+			continue
+		}
+		mutating = mutating || res.Webhooks.Defaulting
+		validating = validating || res.Webhooks.Validation
+	}
+	return mutating, validating, nil
 }
@@ -17,7 +17,13 @@ limitations under the License.
 package v3
 
 import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/pflag"
+
 	"github.com/labring/kubebuilder4helm/plugins/helm/v3/scaffolds"
+	"sigs.k8s.io/kubebuilder/v3/pkg/config"
 	"sigs.k8s.io/kubebuilder/v3/pkg/machinery"
 	"sigs.k8s.io/kubebuilder/v3/pkg/plugin"
 )
@@ -26,13 +32,62 @@ var _ plugin.CreateWebhookSubcommand = &createWebhookSubcommand{}
 
 type createWebhookSubcommand struct {
 	createSubcommand
+
+	// certProvider selects how the webhook serving certificate is provisioned
+	certProvider string
+
+	// skipCertManagerCheck skips the hook waiting for cert-manager to be reachable before install
+	skipCertManagerCheck bool
+}
+
+func (p *createWebhookSubcommand) BindFlags(fs *pflag.FlagSet) {
+	p.createSubcommand.BindFlags(fs)
+
+	fs.StringVar(&p.certProvider, "cert-provider", "",
+		fmt.Sprintf("provisioner of the webhook serving certificate, %q or %q to generate it with helm "+
+			"without depending on cert-manager, defaults to the one of the existing webhooks or %q",
+			scaffolds.CertManagerProvider, scaffolds.HelmCertProvider, scaffolds.CertManagerProvider))
+	fs.BoolVar(&p.skipCertManagerCheck, "skip-webhook-cert-manager-check", false,
+		"if set, don't scaffold the hook waiting for the cert-manager webhook before installing the chart, "+
+			"e.g. when cert-manager is reachable under another address, for this and the next webhooks")
 }
 
 func (p *createWebhookSubcommand) Scaffold(fs machinery.Filesystem) error {
 	if err := p.configure(); err != nil {
 		return err
 	}
-	scaffolder := scaffolds.NewWebhookScaffolder(p.config, *p.resource, p.force)
+
+	cfg := pluginConfig{}
+	if err := p.config.DecodePluginConfig(pluginKey, &cfg); err != nil && !errors.As(err, &config.PluginKeyNotFoundError{}) {
+		return err
+	}
+	switch {
+	case p.certProvider == "":
+	case p.certProvider != scaffolds.CertManagerProvider && p.certProvider != scaffolds.HelmCertProvider:
+		return fmt.Errorf("unsupported --cert-provider %q, must be %q or %q",
+			p.certProvider, scaffolds.CertManagerProvider, scaffolds.HelmCertProvider)
+	case cfg.CertProvider != "" && cfg.CertProvider != p.certProvider:
+		return fmt.Errorf("the existing webhooks use the %q certificate provider, --cert-provider can't be changed to %q",
+			cfg.CertProvider, p.certProvider)
+	default:
+		cfg.CertProvider = p.certProvider
+	}
+	if cfg.CertProvider == "" {
+		cfg.CertProvider = scaffolds.CertManagerProvider
+	}
+	if p.skipCertManagerCheck {
+		cfg.SkipCertManagerCheck = true
+	}
+	if err := p.config.EncodePluginConfig(pluginKey, cfg); err != nil {
+		return err
+	}
+
+	chartDir, err := ChartDir(p.config)
+	if err != nil {
+		return err
+	}
+	scaffolder := scaffolds.NewWebhookScaffolder(p.config, *p.resource, p.force, cfg.initOptions(chartDir),
+		cfg.webhookOptions())
 	scaffolder.InjectFS(fs)
 	return scaffolder.Scaffold()
 }